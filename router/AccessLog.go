@@ -0,0 +1,371 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Rafael24595/go-web/router/log"
+	"github.com/Rafael24595/go-web/router/websocket"
+)
+
+const ACCESS string = "ACCESS"
+
+// AccessEntry describes a handled request, as written by the AccessLog.
+// URI is the request target as sent by the client, while Path is decoded.
+type AccessEntry struct {
+	Time      time.Time     `json:"time"`
+	Method    string        `json:"method"`
+	Pattern   string        `json:"pattern"`
+	URI       string        `json:"uri"`
+	Path      string        `json:"path"`
+	Query     string        `json:"query,omitempty"`
+	Proto     string        `json:"proto"`
+	Status    int           `json:"status"`
+	Bytes     int64         `json:"bytes"`
+	Duration  time.Duration `json:"duration"`
+	Remote    string        `json:"remote"`
+	User      string        `json:"user,omitempty"`
+	Referer   string        `json:"referer,omitempty"`
+	UserAgent string        `json:"user_agent,omitempty"`
+	RequestID string        `json:"request_id,omitempty"`
+}
+
+// AccessFormatter renders an AccessEntry into a log line.
+type AccessFormatter = func(entry AccessEntry) string
+
+// AccessLog represents the configuration of the request access log of the
+// Router. Each request is written once its response is complete, through
+// the configured log.Log with the ACCESS category; loggers supporting
+// attributes also receive every field of the entry.
+type AccessLog struct {
+	logger    log.Log
+	formatter AccessFormatter
+	proxies   []netip.Prefix
+}
+
+// NewAccessLog creates a new AccessLog writing the Combined Log Format
+// through the Router logger.
+func NewAccessLog() *AccessLog {
+	return &AccessLog{
+		logger:    nil,
+		formatter: CombinedLogFormat,
+		proxies:   make([]netip.Prefix, 0),
+	}
+}
+
+// Logger sets the logger of the access log, instead of the Router one.
+//
+// Returns the AccessLog instance for fluent configuration.
+func (a *AccessLog) Logger(logger log.Log) *AccessLog {
+	a.logger = logger
+	return a
+}
+
+// Common selects the Common Log Format.
+//
+// Returns the AccessLog instance for fluent configuration.
+func (a *AccessLog) Common() *AccessLog {
+	return a.Formatter(CommonLogFormat)
+}
+
+// Combined selects the Combined Log Format.
+//
+// Returns the AccessLog instance for fluent configuration.
+func (a *AccessLog) Combined() *AccessLog {
+	return a.Formatter(CombinedLogFormat)
+}
+
+// Json selects one JSON object per request.
+//
+// Returns the AccessLog instance for fluent configuration.
+func (a *AccessLog) Json() *AccessLog {
+	return a.Formatter(JsonLogFormat)
+}
+
+// Template selects a text/template rendered with the AccessEntry, e.g.
+// `{{.Method}} {{.Path}} {{.Status}} {{.Duration}}`. It panics if the
+// template cannot be parsed.
+//
+// Returns the AccessLog instance for fluent configuration.
+func (a *AccessLog) Template(text string) *AccessLog {
+	tmpl := template.Must(template.New("access").Parse(text))
+	return a.Formatter(func(entry AccessEntry) string {
+		var builder strings.Builder
+		if err := tmpl.Execute(&builder, entry); err != nil {
+			return err.Error()
+		}
+		return builder.String()
+	})
+}
+
+// Formatter selects a custom function rendering each entry.
+//
+// Returns the AccessLog instance for fluent configuration.
+func (a *AccessLog) Formatter(formatter AccessFormatter) *AccessLog {
+	a.formatter = formatter
+	return a
+}
+
+// TrustedProxies sets the addresses or CIDR ranges of the proxies whose
+// X-Forwarded-For and X-Real-IP headers are trusted to report the client
+// address. It panics if any of them cannot be parsed.
+//
+// Returns the AccessLog instance for fluent configuration.
+func (a *AccessLog) TrustedProxies(proxies ...string) *AccessLog {
+	prefixes := make([]netip.Prefix, 0, len(proxies))
+	for _, v := range proxies {
+		if !strings.Contains(v, "/") {
+			addr := netip.MustParseAddr(v)
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefixes = append(prefixes, netip.MustParsePrefix(v))
+	}
+
+	a.proxies = prefixes
+	return a
+}
+
+// AccessLog enables the access log of the Router. Pass nil to disable it.
+//
+// Example:
+//
+//	router.AccessLog(router.NewAccessLog().Json().TrustedProxies("10.0.0.0/8"))
+//
+// Returns the Router itself for fluent configuration.
+func (r *Router) AccessLog(access *AccessLog) *Router {
+	r.accessLog = access
+	return r
+}
+
+// CommonLogFormat renders an entry in the Common Log Format. Quotes,
+// backslashes and non-printable characters of the client provided fields,
+// and spaces outside the quoted ones, are escaped as \xHH so they cannot
+// forge other fields.
+func CommonLogFormat(entry AccessEntry) string {
+	return fmt.Sprintf(`%s - %s [%s] "%s %s %s" %d %s`,
+		orDash(entry.Remote),
+		orDash(escapeField(entry.User, true)),
+		entry.Time.Format("02/Jan/2006:15:04:05 -0700"),
+		escapeField(entry.Method, true),
+		escapeField(entry.uri(), true),
+		escapeField(entry.Proto, true),
+		entry.Status,
+		entry.size())
+}
+
+// CombinedLogFormat renders an entry in the Combined Log Format, escaped
+// as CommonLogFormat.
+func CombinedLogFormat(entry AccessEntry) string {
+	return fmt.Sprintf(`%s "%s" "%s"`,
+		CommonLogFormat(entry),
+		orDash(escapeField(entry.Referer, false)),
+		orDash(escapeField(entry.UserAgent, false)))
+}
+
+// JsonLogFormat renders an entry as a JSON object.
+func JsonLogFormat(entry AccessEntry) string {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+func (e AccessEntry) uri() string {
+	if e.URI != "" {
+		return e.URI
+	}
+	if e.Query == "" {
+		return e.Path
+	}
+	return e.Path + "?" + e.Query
+}
+
+func (e AccessEntry) size() string {
+	if e.Bytes == 0 {
+		return "-"
+	}
+	return strconv.FormatInt(e.Bytes, 10)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func escapeField(value string, space bool) string {
+	var builder strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c < ' ' || c >= 0x7f || c == '"' || c == '\\' || (space && c == ' ') {
+			fmt.Fprintf(&builder, "\\x%02x", c)
+			continue
+		}
+		builder.WriteByte(c)
+	}
+	return builder.String()
+}
+
+func (a *AccessLog) record(logger log.Log, wrt *accessWriter, req *http.Request, pattern string, start time.Time) {
+	if a.logger != nil {
+		logger = a.logger
+	}
+
+	status := wrt.status
+	if status == 0 {
+		status = http.StatusOK
+		if websocket.IsUpgrade(req) {
+			status = http.StatusSwitchingProtocols
+		}
+	}
+
+	user, _, _ := req.BasicAuth()
+
+	entry := AccessEntry{
+		Time:      start,
+		Method:    req.Method,
+		Pattern:   pattern,
+		URI:       requestURI(req),
+		Path:      req.URL.Path,
+		Query:     req.URL.RawQuery,
+		Proto:     req.Proto,
+		Status:    status,
+		Bytes:     wrt.bytes,
+		Duration:  time.Since(start),
+		Remote:    a.remote(req),
+		User:      user,
+		Referer:   req.Referer(),
+		UserAgent: req.UserAgent(),
+		RequestID: req.Header.Get("X-Request-Id"),
+	}
+
+	log.Attrs(logger, ACCESS, a.formatter(entry),
+		slog.String(log.METHOD, entry.Method),
+		slog.String(log.ROUTE, entry.Pattern),
+		slog.String(log.PATH, entry.Path),
+		slog.Int(log.STATUS, entry.Status),
+		slog.Int64("bytes", entry.Bytes),
+		slog.Duration(log.LATENCY, entry.Duration),
+		slog.String(log.REMOTE, entry.Remote),
+		slog.String("user_agent", entry.UserAgent),
+		slog.String(log.REQUEST_ID, entry.RequestID))
+}
+
+func requestURI(req *http.Request) string {
+	if req.RequestURI != "" {
+		return req.RequestURI
+	}
+	return req.URL.RequestURI()
+}
+
+// remote returns the client address, read from the forwarding headers
+// only when the request comes from a trusted proxy. X-Forwarded-For is
+// walked from the right, skipping trusted proxies and stopping at the
+// first value that is not an address.
+func (a *AccessLog) remote(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+
+	if !a.trusted(host) {
+		return host
+	}
+
+	forwarded := strings.Split(strings.Join(req.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		value := strings.TrimSpace(forwarded[i])
+		if value == "" {
+			continue
+		}
+
+		addr, ok := parseHop(value)
+		if !ok {
+			return host
+		}
+		if !a.trusted(addr) {
+			return addr
+		}
+		host = addr
+	}
+
+	if real, ok := parseHop(strings.TrimSpace(req.Header.Get("X-Real-IP"))); ok {
+		return real
+	}
+
+	return host
+}
+
+// parseHop parses a forwarded address, with or without a port, and
+// returns it in its canonical form.
+func parseHop(value string) (string, bool) {
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		addrPort, err := netip.ParseAddrPort(value)
+		if err != nil {
+			return "", false
+		}
+		addr = addrPort.Addr()
+	}
+	return addr.Unmap().String(), true
+}
+
+func (a *AccessLog) trusted(host string) bool {
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+	for _, prefix := range a.proxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// accessWriter records the status and the number of body bytes written.
+type accessWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *accessWriter) WriteHeader(status int) {
+	if w.status == 0 && status >= http.StatusOK {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *accessWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(data)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *accessWriter) Flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *accessWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,182 @@
+package router
+
+import (
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+var (
+	fileHeaderType  = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeadersType = reflect.TypeOf([]*multipart.FileHeader(nil))
+)
+
+// bindValues binds the given values into the struct pointed by target,
+// matching each field with the key defined by the given tag.
+//
+// Fields without tag are bound by their name, and fields tagged with "-"
+// are ignored. The following tags are also supported:
+//   - default: value used when the key is not present (comma separated for slices only).
+//   - layout: time layout used to parse time.Time fields (RFC 3339 by default).
+//
+// Slice fields take every value of the key, pointer fields remain nil when
+// the key is not present, and embedded structs are bound recursively.
+// It returns one error for each field that cannot be bound.
+func bindValues(values url.Values, tag string, target any) []error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return []error{fmt.Errorf("cannot bind values into %T, a struct is required", target)}
+	}
+
+	return bindStruct(values, tag, value.Elem())
+}
+
+func bindStruct(values url.Values, tag string, value reflect.Value) []error {
+	errs := make([]error, 0)
+
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			errs = append(errs, bindStruct(values, tag, value.Field(i))...)
+			continue
+		}
+
+		if !field.IsExported() || isFileField(field) {
+			continue
+		}
+
+		name, ok := bindName(field, tag)
+		if !ok {
+			continue
+		}
+
+		raw, ok := values[name]
+		if !ok || len(raw) == 0 {
+			def, ok := field.Tag.Lookup("default")
+			if !ok {
+				continue
+			}
+			raw = []string{def}
+			if isSliceField(field.Type) {
+				raw = strings.Split(def, ",")
+			}
+		}
+
+		if err := bindField(raw, field, value.Field(i)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	return errs
+}
+
+func bindName(field reflect.StructField, tag string) (string, bool) {
+	name := strings.Split(field.Tag.Get(tag), ",")[0]
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		return field.Name, true
+	}
+	return name, true
+}
+
+func bindField(raw []string, field reflect.StructField, value reflect.Value) error {
+	layout := field.Tag.Get("layout")
+	t := field.Type
+
+	isPointer := t.Kind() == reflect.Ptr
+	if isPointer {
+		t = t.Elem()
+	}
+
+	if isSliceField(t) {
+		slice := reflect.MakeSlice(t, 0, len(raw))
+		for _, item := range raw {
+			parsed, err := parseText(item, t.Elem(), layout)
+			if err != nil {
+				return err
+			}
+			slice = reflect.Append(slice, parsed)
+		}
+		setField(value, slice, isPointer)
+		return nil
+	}
+
+	parsed, err := parseText(raw[0], t, layout)
+	if err != nil {
+		return err
+	}
+
+	setField(value, parsed, isPointer)
+	return nil
+}
+
+// isSliceField reports whether the type, or the one it points to, is bound
+// from every value of its key instead of the first one.
+func isSliceField(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Slice && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+func setField(field reflect.Value, value reflect.Value, isPointer bool) {
+	if isPointer {
+		pointer := reflect.New(value.Type())
+		pointer.Elem().Set(value)
+		value = pointer
+	}
+	field.Set(value)
+}
+
+// bindFiles binds the given uploaded files into the struct pointed by
+// target. Only fields of type *multipart.FileHeader or
+// []*multipart.FileHeader are considered, matched as in bindValues.
+func bindFiles(files map[string][]*multipart.FileHeader, tag string, target any) {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return
+	}
+
+	bindFileStruct(files, tag, value.Elem())
+}
+
+func bindFileStruct(files map[string][]*multipart.FileHeader, tag string, value reflect.Value) {
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			bindFileStruct(files, tag, value.Field(i))
+			continue
+		}
+
+		if !field.IsExported() || !isFileField(field) {
+			continue
+		}
+
+		name, ok := bindName(field, tag)
+		if !ok {
+			continue
+		}
+
+		headers, ok := files[name]
+		if !ok || len(headers) == 0 {
+			continue
+		}
+
+		if field.Type == fileHeadersType {
+			value.Field(i).Set(reflect.ValueOf(headers))
+			continue
+		}
+
+		value.Field(i).Set(reflect.ValueOf(headers[0]))
+	}
+}
+
+func isFileField(field reflect.StructField) bool {
+	return field.Type == fileHeaderType || field.Type == fileHeadersType
+}
@@ -0,0 +1,333 @@
+package router
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Compressor is a streaming compressor for a response body, as
+// implemented by gzip.Writer and zlib.Writer.
+type Compressor interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// CompressorFactory builds a Compressor writing into w with the given level.
+type CompressorFactory = func(w io.Writer, level int) (Compressor, error)
+
+type compressionEncoding struct {
+	name    string
+	factory CompressorFactory
+}
+
+// Compression represents the configuration of response compression on
+// the Router.
+//
+// The encoding is negotiated from the request Accept-Encoding header,
+// honoring q-values and the order in which encodings were configured.
+// Responses are compressed only when their content type is allowed and
+// their body reaches the minimum size; streamed responses are compressed
+// as soon as they are flushed.
+type Compression struct {
+	level        int
+	minSize      int
+	contentTypes []string
+	encodings    []compressionEncoding
+}
+
+// EmptyCompression creates a new Compression instance without encodings,
+// which leaves every response untouched until configured.
+func EmptyCompression() *Compression {
+	return &Compression{
+		level:        gzip.DefaultCompression,
+		minSize:      0,
+		contentTypes: make([]string, 0),
+		encodings:    make([]compressionEncoding, 0),
+	}
+}
+
+// DefaultCompression returns a Compression instance supporting gzip and
+// deflate, for bodies of at least 1 KiB of textual content types
+// (text/*, JSON, XML, JavaScript, NDJSON, problem+json and SVG).
+func DefaultCompression() *Compression {
+	return EmptyCompression().
+		Encoding("gzip", func(w io.Writer, level int) (Compressor, error) {
+			return gzip.NewWriterLevel(w, level)
+		}).
+		Encoding("deflate", func(w io.Writer, level int) (Compressor, error) {
+			return zlib.NewWriterLevel(w, level)
+		}).
+		MinSize(1024).
+		ContentTypes(
+			"text/*",
+			"application/json",
+			"application/xml",
+			"application/javascript",
+			"application/x-ndjson",
+			"application/problem+json",
+			"image/svg+xml",
+		)
+}
+
+// Encoding registers a content coding, e.g. "br" backed by a third-party
+// brotli writer. Encodings registered first are preferred when the client
+// accepts several with the same quality.
+//
+// Returns the Compression instance for fluent configuration.
+func (c *Compression) Encoding(name string, factory CompressorFactory) *Compression {
+	c.encodings = append(c.encodings, compressionEncoding{
+		name:    strings.ToLower(name),
+		factory: factory,
+	})
+	return c
+}
+
+// Level sets the compression level passed to the compressors.
+//
+// Returns the Compression instance for fluent configuration.
+func (c *Compression) Level(level int) *Compression {
+	c.level = level
+	return c
+}
+
+// MinSize sets the minimum body size in bytes to compress a response.
+//
+// Returns the Compression instance for fluent configuration.
+func (c *Compression) MinSize(size int) *Compression {
+	c.minSize = size
+	return c
+}
+
+// ContentTypes sets the media types eligible for compression. A type
+// ending in "/*" matches a whole family, such as "text/*".
+//
+// Returns the Compression instance for fluent configuration.
+func (c *Compression) ContentTypes(contentTypes ...string) *Compression {
+	c.contentTypes = contentTypes
+	return c
+}
+
+func (c *Compression) allows(contentType string) bool {
+	media, _, _ := strings.Cut(contentType, ";")
+	media = strings.ToLower(strings.TrimSpace(media))
+
+	for _, v := range c.contentTypes {
+		if family, ok := strings.CutSuffix(v, "/*"); ok {
+			if strings.HasPrefix(media, family+"/") {
+				return true
+			}
+			continue
+		}
+		if media == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// negotiate returns the configured encoding preferred by the client.
+func (c *Compression) negotiate(acceptEncoding string) (compressionEncoding, bool) {
+	qualities := make(map[string]float64)
+	for _, item := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(item, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		qualities[name] = quality
+	}
+
+	best := -1
+	quality := 0.0
+	for i, v := range c.encodings {
+		q, ok := qualities[v.name]
+		if !ok {
+			q = qualities["*"]
+		}
+		if q > quality {
+			best, quality = i, q
+		}
+	}
+
+	if best < 0 {
+		return compressionEncoding{}, false
+	}
+	return c.encodings[best], true
+}
+
+// Compression enables response compression for every request handled by
+// the Router, including streams and static resources. Pass nil to
+// disable it.
+//
+// Example:
+//
+//	router.Compression(router.DefaultCompression().MinSize(512))
+//
+// Returns the Router itself for fluent configuration.
+func (r *Router) Compression(compression *Compression) *Router {
+	r.compression = compression
+	return r
+}
+
+// compressWriter buffers the beginning of the body until it can decide
+// whether to compress it, based on status, headers and size.
+type compressWriter struct {
+	http.ResponseWriter
+	compression *Compression
+	encoding    compressionEncoding
+	head        bool
+	status      int
+	decided     bool
+	compress    bool
+	buffer      []byte
+	writer      Compressor
+}
+
+func newCompressWriter(wrt http.ResponseWriter, req *http.Request, compression *Compression, encoding compressionEncoding) *compressWriter {
+	return &compressWriter{
+		ResponseWriter: wrt,
+		compression:    compression,
+		encoding:       encoding,
+		head:           req.Method == http.MethodHead,
+	}
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+
+	if status < http.StatusOK {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	w.status = status
+
+	if length := w.Header().Get("Content-Length"); length != "" {
+		size, err := strconv.Atoi(length)
+		w.decide(err == nil && size >= w.compression.minSize)
+	}
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.decided {
+		if w.compress {
+			return w.writer.Write(data)
+		}
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buffer = append(w.buffer, data...)
+	if len(w.buffer) >= w.compression.minSize {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(data), nil
+}
+
+// Flush sends the buffered body, compressing it regardless of its size
+// since a flushing handler is streaming.
+func (w *compressWriter) Flush() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.decided {
+		w.decide(true)
+	}
+	if w.compress {
+		w.writer.Flush()
+	}
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Close completes the compressed stream, or sends the small uncompressed
+// body still buffered.
+func (w *compressWriter) Close() error {
+	if w.status == 0 {
+		return nil
+	}
+	if !w.decided {
+		if err := w.decide(false); err != nil {
+			return err
+		}
+	}
+	if w.compress {
+		return w.writer.Close()
+	}
+	return nil
+}
+
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressWriter) decide(candidate bool) error {
+	w.decided = true
+
+	header := w.Header()
+	if header.Get("Content-Type") == "" && len(w.buffer) > 0 {
+		header.Set("Content-Type", http.DetectContentType(w.buffer))
+	}
+
+	allowed := w.compression.allows(header.Get("Content-Type"))
+	if allowed {
+		header.Add("Vary", "Accept-Encoding")
+	}
+
+	w.compress = candidate && allowed && !w.head &&
+		w.status != http.StatusNoContent &&
+		w.status != http.StatusPartialContent &&
+		w.status != http.StatusNotModified &&
+		header.Get("Content-Encoding") == "" &&
+		header.Get("Content-Range") == ""
+
+	if w.compress {
+		writer, err := w.encoding.factory(w.ResponseWriter, w.compression.level)
+		if err != nil {
+			w.compress = false
+		} else {
+			w.writer = writer
+			header.Del("Content-Length")
+			header.Set("Content-Encoding", w.encoding.name)
+			if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+				header.Set("ETag", "W/"+etag)
+			}
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	buffer := w.buffer
+	w.buffer = nil
+	if len(buffer) == 0 {
+		return nil
+	}
+
+	if w.compress {
+		_, err := w.writer.Write(buffer)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buffer)
+	return err
+}
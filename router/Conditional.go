@@ -0,0 +1,188 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+// Preconditions evaluates the conditional headers of the request against
+// the current state of a resource, identified by its entity tag (as
+// accepted by result.FormatETag) and its modification time. Either of
+// them may be empty.
+//
+// Handlers performing updates should call it before applying any change,
+// so that a stale If-Match or If-Unmodified-Since is rejected with 412
+// Precondition Failed. A matching If-None-Match or an unmodified
+// If-Modified-Since on GET and HEAD returns 304 Not Modified. It returns
+// nil when the request may proceed.
+//
+// Example:
+//
+//	func update(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+//	    item := store.Find(r.PathValue("id"))
+//	    if res := router.Preconditions(r, item.Version, item.Updated); res != nil {
+//	        return *res
+//	    }
+//	    ...
+//	}
+func Preconditions(r *http.Request, etag string, modified time.Time) *result.Result {
+	if etag != "" {
+		etag = result.FormatETag(etag, false)
+	}
+
+	switch status := evaluatePreconditions(r, etag, modified); status {
+	case http.StatusNotModified:
+		res := result.Accept(status)
+		if etag != "" {
+			res = res.WithETag(etag)
+		}
+		return &res
+	case http.StatusPreconditionFailed:
+		res := result.ProblemErr(status, fmt.Errorf("the resource does not match the request preconditions"))
+		return &res
+	}
+
+	return nil
+}
+
+// evaluatePreconditions applies the precedence of RFC 9110, section
+// 13.2.2, and returns 304, 412 or 0 when the request is unconditional
+// or its conditions hold.
+func evaluatePreconditions(r *http.Request, etag string, modified time.Time) int {
+	modified = modified.Truncate(time.Second)
+
+	if match := headerList(r, "If-Match"); match != "" {
+		if !matchETag(match, etag, false) {
+			return http.StatusPreconditionFailed
+		}
+	} else if since, ok := headerTime(r, "If-Unmodified-Since"); ok && !modified.IsZero() {
+		if modified.After(since) {
+			return http.StatusPreconditionFailed
+		}
+	}
+
+	safe := r.Method == http.MethodGet || r.Method == http.MethodHead
+
+	if none := headerList(r, "If-None-Match"); none != "" {
+		if matchETag(none, etag, true) {
+			if safe {
+				return http.StatusNotModified
+			}
+			return http.StatusPreconditionFailed
+		}
+	} else if since, ok := headerTime(r, "If-Modified-Since"); ok && safe && !modified.IsZero() {
+		if !modified.After(since) {
+			return http.StatusNotModified
+		}
+	}
+
+	return 0
+}
+
+func headerList(r *http.Request, key string) string {
+	return strings.Join(r.Header.Values(key), ",")
+}
+
+func headerTime(r *http.Request, key string) (time.Time, bool) {
+	value := r.Header.Get(key)
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// matchETag reports whether the entity tag is listed in the header, using
+// the weak or the strong comparison function.
+func matchETag(header, etag string, weak bool) bool {
+	if etag == "" {
+		return false
+	}
+
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+
+	target, targetWeak := splitETag(etag)
+	if !weak && targetWeak {
+		return false
+	}
+
+	for {
+		header = strings.TrimLeft(header, " \t,")
+		if header == "" {
+			return false
+		}
+
+		tagWeak := strings.HasPrefix(header, "W/")
+		if tagWeak {
+			header = header[2:]
+		}
+
+		if !strings.HasPrefix(header, `"`) {
+			return false
+		}
+
+		end := strings.IndexByte(header[1:], '"')
+		if end < 0 {
+			return false
+		}
+
+		tag := header[:end+2]
+		header = header[end+2:]
+
+		if tag == target && (weak || !tagWeak) {
+			return true
+		}
+	}
+}
+
+func splitETag(etag string) (string, bool) {
+	if tag, ok := strings.CutPrefix(etag, "W/"); ok {
+		return tag, true
+	}
+	return etag, false
+}
+
+// conditional answers the request with 304 or 412 when the result has an
+// entity tag or modification time that fails the request preconditions.
+// Only successful results of GET and HEAD requests are evaluated: the
+// result of an unsafe method describes the resource after the change has
+// been applied, so those must be checked up front with Preconditions.
+func (r *Router) conditional(wrt http.ResponseWriter, req *http.Request, ctx *Context, res result.Result) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+
+	if res.Status() < 200 || res.Status() > 299 {
+		return false
+	}
+
+	etag := res.ETag()
+	modified := res.LastModified()
+	if etag == "" && modified.IsZero() {
+		return false
+	}
+
+	switch evaluatePreconditions(req, etag, modified) {
+	case http.StatusNotModified:
+		r.writeHeaders(wrt, res, nil)
+		wrt.Header().Del("Content-Type")
+		wrt.WriteHeader(http.StatusNotModified)
+		return true
+	case http.StatusPreconditionFailed:
+		r.manageErr(wrt, req, ctx, result.ProblemErr(http.StatusPreconditionFailed,
+			fmt.Errorf("the resource does not match the request preconditions")))
+		return true
+	}
+
+	return false
+}
@@ -1,14 +1,15 @@
 package router
 
 import (
-	"bytes"
-	"encoding/json"
-	"encoding/xml"
+	"errors"
+	"fmt"
 	"io"
+	"mime"
 	"net/http"
 
+	"github.com/Rafael24595/go-web/router/docs"
 	"github.com/Rafael24595/go-web/router/result"
-	"golang.org/x/net/html/charset"
+	"github.com/Rafael24595/go-web/router/validator"
 )
 
 // InputOpts defines options for reading HTTP request bodies.
@@ -19,9 +20,13 @@ import (
 //
 // Fields:
 //   - Limit: maximum number of bytes to read from the request body.
+//            Compressed bodies are measured once decompressed.
 //            Set to 0 for no limit.
 //   - Strict: if true, reading more than Limit bytes will return an error.
 //             If false, the reader will return only up to Limit bytes without error.
+//   - FileLimit: maximum size in bytes of each uploaded file, used by the
+//                multipart input functions. Set to 0 for no limit. It is
+//                checked after parsing, so Limit bounds the body read.
 //
 // Example:
 //
@@ -30,9 +35,14 @@ import (
 //       Strict: true,       // error if exceeded
 //   }
 //   data, res := router.InputBytesWithOpts(w, r, opts)
+//
+// Request bodies sent with Content-Encoding gzip or deflate are
+// decompressed transparently by every input function; other encodings
+// are rejected with 415 Unsupported Media Type.
 type InputOpts struct {
-	Strict bool  // whether to enforce the limit strictly
-	Limit  int64 // maximum number of bytes to read; 0 = unlimited
+	Strict    bool  // whether to enforce the limit strictly
+	Limit     int64 // maximum number of bytes to read; 0 = unlimited
+	FileLimit int64 // maximum number of bytes of each uploaded file; 0 = unlimited
 }
 
 // InputBytes reads the entire request body as raw bytes.
@@ -52,9 +62,11 @@ type InputOpts struct {
 //	    return result.BytesOk(data)
 //	}
 func InputBytes(r *http.Request) ([]byte, *result.Result) {
-	defer r.Body.Close()
+	// The body is replaced by its decompressed reader, which must be the
+	// one closed to release the decompressors.
+	defer func() { r.Body.Close() }()
 
-	raw, res := readAllBytes(r)
+	raw, res := readOptBytes(nil, r, 0, false)
 	if res != nil {
 		return raw, res
 	}
@@ -89,6 +101,10 @@ func InputText(r *http.Request) (string, *result.Result) {
 // the zero value of T and a non-nil *result.Result with status
 // 422 Unprocessable Entity. The request body is always closed by this function.
 //
+// The decoded payload is checked against its `validate` tags (see
+// package validator); invalid fields are reported as a 422 problem
+// listing the field errors.
+//
 // Example:
 //
 //	type User struct {
@@ -146,6 +162,77 @@ func InputXml[T any](r *http.Request) (T, *result.Result) {
 	return xmlDecode[T](raw)
 }
 
+// Input decodes the request body into a value of type T, selecting the
+// decoder according to the request Content-Type.
+//
+// JSON, XML and form-urlencoded bodies are supported by default, and new
+// formats can be added with RegisterDecoder. Use DocInputPayload to
+// document every accepted media type.
+//
+// If no decoder is registered for the request Content-Type, it returns the
+// zero value of T and a non-nil *result.Result with status 415 Unsupported
+// Media Type. Decoding errors are reported as in InputJson, InputXml and
+// InputForm. The request body is always closed by this function.
+//
+// Like every Input helper, failures are returned as
+// application/problem+json results (see result.Problem).
+//
+// Example:
+//
+//	func handler(w http.ResponseWriter, r *http.Request, ctx router.Context) result.Result {
+//	    user, res := router.Input[User](r)
+//	    if res != nil {
+//	        return *res
+//	    }
+//	    return result.JsonOk(user)
+//	}
+func Input[T any](r *http.Request) (T, *result.Result) {
+	return InputWithOpts[T](nil, r, InputOpts{})
+}
+
+// InputWithOpts decodes the request body into a value of type T according
+// to the request Content-Type, with additional options.
+//
+// The request body is always closed by this function. Use InputOpts to specify:
+//   - Limit: maximum number of bytes to read (0 means no limit)
+//   - Strict: whether to return an error if the limit is exceeded
+//
+// Errors are reported as in Input, with status 413 Request Entity Too Large
+// when the limit is exceeded in strict mode.
+func InputWithOpts[T any](w http.ResponseWriter, r *http.Request, opts InputOpts) (T, *result.Result) {
+	var payload T
+
+	media, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	decoder, ok := findDecoder(media)
+	if err != nil || !ok {
+		r.Body.Close()
+		result := result.ProblemErr(http.StatusUnsupportedMediaType,
+			fmt.Errorf("unsupported content type '%s'", r.Header.Get("Content-Type")))
+		return payload, &result
+	}
+
+	raw, res := InputBytesWithOpts(w, r, opts)
+	if res != nil {
+		return payload, res
+	}
+
+	return decode[T](decoder, raw)
+}
+
+// DocInputPayload creates a DocPayload for a request body decoded with
+// Input, documenting every media type with a registered decoder.
+func DocInputPayload[T any](description ...string) docs.DocPayload {
+	payload := docs.DocJsonPayload[T](description...)
+
+	media := Decoders()
+	payload.MediaType = docs.MediaType(media[0])
+	for _, v := range media[1:] {
+		payload.Accepts = append(payload.Accepts, docs.MediaType(v))
+	}
+
+	return payload
+}
+
 // InputBytesWithOpts reads the entire request body as raw bytes with additional options.
 //
 // The request body is always closed by this function. Use InputOpts to specify:
@@ -167,7 +254,9 @@ func InputXml[T any](r *http.Request) (T, *result.Result) {
 //	    return result.BytesOk(data)
 //	}
 func InputBytesWithOpts(w http.ResponseWriter, r *http.Request, opts InputOpts) ([]byte, *result.Result) {
-	defer r.Body.Close()
+	// The body is replaced by its decompressed reader, which must be the
+	// one closed to release the decompressors.
+	defer func() { r.Body.Close() }()
 
 	raw, res := readOptBytes(w, r, opts.Limit, opts.Strict)
 	if res != nil {
@@ -271,31 +360,53 @@ func InputXmlWithOpts[T any](w http.ResponseWriter, r *http.Request, opts InputO
 }
 
 func jsonDecode[T any](raw []byte) (T, *result.Result) {
+	return decode[T](NewJsonDecoder(), raw)
+}
+
+func xmlDecode[T any](raw []byte) (T, *result.Result) {
+	return decode[T](NewXmlDecoder(), raw)
+}
+
+func decode[T any](decoder RequestDecoder, raw []byte) (T, *result.Result) {
 	var payload T
 
-	err := json.Unmarshal(raw, &payload)
-	if err != nil {
-		result := result.Err(http.StatusUnprocessableEntity, err)
+	if err := decoder.Decode(raw, &payload); err != nil {
+		if bind := new(BindError); errors.As(err, &bind) {
+			result := result.ProblemErr(http.StatusBadRequest, bind.Errors...)
+			return payload, &result
+		}
+		result := result.ProblemErr(http.StatusUnprocessableEntity, err)
 		return payload, &result
 	}
 
-	return payload, nil
+	return payload, validate(&payload)
 }
 
-func xmlDecode[T any](raw []byte) (T, *result.Result) {
-	var payload T
+// validate runs the `validate` tag rules over a decoded payload. Invalid
+// fields produce a 422 problem listing each field path under "errors",
+// while a malformed tag is reported as a 500 since it is a programming error.
+func validate(payload any) *result.Result {
+	err := validator.Validate(payload)
+	if err == nil {
+		return nil
+	}
 
-	decoder := xml.NewDecoder(bytes.NewReader(raw))
-	decoder.CharsetReader = charset.NewReaderLabel
-	if err := decoder.Decode(&payload); err != nil {
-		result := result.Err(http.StatusUnprocessableEntity, err)
-		return payload, &result
+	if errs := make(validator.Errors, 0); errors.As(err, &errs) {
+		problem := result.NewProblem(http.StatusUnprocessableEntity, "the payload has invalid fields").
+			WithExtension("errors", errs)
+		result := result.FromProblem(problem)
+		return &result
 	}
 
-	return payload, nil
+	result := result.Err(http.StatusInternalServerError, err)
+	return &result
 }
 
 func readOptBytes(w http.ResponseWriter, r *http.Request, limit int64, strict bool) ([]byte, *result.Result) {
+	if res := decompressBody(r); res != nil {
+		return make([]byte, 0), res
+	}
+
 	if limit <= 0 {
 		return readAllBytes(r)
 	}
@@ -310,7 +421,7 @@ func readOptBytes(w http.ResponseWriter, r *http.Request, limit int64, strict bo
 func readAllBytes(r *http.Request) ([]byte, *result.Result) {
 	bytes, err := io.ReadAll(r.Body)
 	if err != nil {
-		result := result.Err(http.StatusUnprocessableEntity, err)
+		result := result.ProblemErr(http.StatusUnprocessableEntity, err)
 		return bytes, &result
 	}
 	return bytes, nil
@@ -319,8 +430,12 @@ func readAllBytes(r *http.Request) ([]byte, *result.Result) {
 func readMaxBytes(w http.ResponseWriter, r *http.Request, limit int64) ([]byte, *result.Result) {
 	r.Body = http.MaxBytesReader(w, r.Body, limit)
 	data, err := io.ReadAll(r.Body)
+	if maxBytes := new(http.MaxBytesError); errors.As(err, &maxBytes) {
+		res := result.ProblemErr(http.StatusRequestEntityTooLarge)
+		return data, &res
+	}
 	if err != nil {
-		res := result.Err(http.StatusRequestEntityTooLarge)
+		res := result.ProblemErr(http.StatusUnprocessableEntity, err)
 		return data, &res
 	}
 	return data, nil
@@ -330,7 +445,7 @@ func readLaxBytes(r *http.Request, limit int64) ([]byte, *result.Result) {
 	limited := io.LimitReader(r.Body, limit)
 	data, err := io.ReadAll(limited)
 	if err != nil {
-		res := result.Err(http.StatusUnprocessableEntity, err)
+		res := result.ProblemErr(http.StatusUnprocessableEntity, err)
 		return data, &res
 	}
 	return data, nil
@@ -0,0 +1,119 @@
+package router
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+// decompressedBody reads the decoded request body and closes every
+// decompressor along with the original body.
+type decompressedBody struct {
+	io.Reader
+	closers []io.Closer
+	closed  bool
+}
+
+func (b *decompressedBody) Read(p []byte) (int, error) {
+	if b.closed {
+		return 0, http.ErrBodyReadAfterClose
+	}
+	return b.Reader.Read(p)
+}
+
+func (b *decompressedBody) Close() error {
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+
+	errs := make([]error, 0, len(b.closers))
+	for i := len(b.closers) - 1; i >= 0; i-- {
+		errs = append(errs, b.closers[i].Close())
+	}
+	return errors.Join(errs...)
+}
+
+// decompressBody replaces the request body with a reader of its decoded
+// content according to Content-Encoding, so that read limits apply to the
+// decompressed size. Codings are undone in reverse order of application.
+//
+// Unknown codings return a 415 Unsupported Media Type problem and a
+// malformed compressed stream a 400 Bad Request one, after closing the
+// body. Otherwise the caller must close the replaced r.Body, not the
+// original one.
+func decompressBody(r *http.Request) *result.Result {
+	header := r.Header.Get("Content-Encoding")
+	if header == "" {
+		return nil
+	}
+
+	codings := strings.Split(header, ",")
+	body := &decompressedBody{
+		Reader:  r.Body,
+		closers: []io.Closer{r.Body},
+	}
+
+	for i := len(codings) - 1; i >= 0; i-- {
+		coding := strings.ToLower(strings.TrimSpace(codings[i]))
+
+		var reader io.ReadCloser
+		var err error
+
+		switch coding {
+		case "", "identity":
+			continue
+		case "gzip", "x-gzip":
+			reader, err = gzip.NewReader(body.Reader)
+		case "deflate":
+			reader, err = newDeflateReader(body.Reader)
+		default:
+			body.Close()
+			res := result.ProblemErr(http.StatusUnsupportedMediaType,
+				fmt.Errorf("unsupported content encoding '%s'", coding))
+			return &res
+		}
+
+		if err != nil {
+			body.Close()
+			res := result.ProblemErr(http.StatusBadRequest,
+				fmt.Errorf("malformed %s request body: %w", coding, err))
+			return &res
+		}
+
+		body.Reader = reader
+		body.closers = append(body.closers, reader)
+	}
+
+	r.Body = body
+	r.ContentLength = -1
+	r.Header.Del("Content-Encoding")
+	r.Header.Del("Content-Length")
+
+	return nil
+}
+
+// newDeflateReader reads a "deflate" body, which RFC 9110 defines as a
+// zlib stream, falling back to raw DEFLATE as sent by some clients.
+func newDeflateReader(reader io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(reader)
+
+	header, err := buffered.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+
+	return flate.NewReader(buffered), nil
+}
@@ -0,0 +1,114 @@
+package router
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Rafael24595/go-web/router/docs"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+// NotFound sets the handler executed when no route matches the request path.
+//
+// The handler runs through the same pipeline as any other route: global
+// contextualizer, Router middlewares, error handler and panic handler.
+// By default, a plain-text 404 Not Found error is returned.
+//
+// Returns the Router itself for fluent configuration.
+func (r *Router) NotFound(handler RequestHandler) *Router {
+	r.notFound = handler
+	return r
+}
+
+// NotFoundDocument sets the handler executed when no route matches the
+// request path, and documents its response for every route.
+//
+// The documentation is attached to the routes registered after the call.
+//
+// Returns the Router itself for fluent configuration.
+func (r *Router) NotFoundDocument(handler RequestHandler, doc docs.DocPayload) *Router {
+	r.docViewer.RegisterGroup(r.basePath, docs.DocGroup{
+		Responses: docs.DocResponses{
+			docs.StatusNotFound: doc,
+		},
+	})
+	return r.NotFound(handler)
+}
+
+// MethodNotAllowed sets the handler executed when the request path matches
+// a route, but not its method.
+//
+// The Allow header is populated with the methods accepted by the path
+// before the handler runs. As with NotFound, the handler runs through the
+// regular Router pipeline. By default, a plain-text 405 Method Not Allowed
+// error is returned.
+//
+// Returns the Router itself for fluent configuration.
+func (r *Router) MethodNotAllowed(handler RequestHandler) *Router {
+	r.methodNotAllowed = handler
+	return r
+}
+
+// MethodNotAllowedDocument sets the handler executed when the request method
+// is not allowed, and documents its response for every route.
+//
+// The documentation is attached to the routes registered after the call.
+//
+// Returns the Router itself for fluent configuration.
+func (r *Router) MethodNotAllowedDocument(handler RequestHandler, doc docs.DocPayload) *Router {
+	r.docViewer.RegisterGroup(r.basePath, docs.DocGroup{
+		Responses: docs.DocResponses{
+			docs.StatusMethodNotAllowed: doc,
+		},
+	})
+	return r.MethodNotAllowed(handler)
+}
+
+func (r *Router) dispatch(wrt http.ResponseWriter, req *http.Request) {
+	if _, pattern := r.mux.Handler(req); pattern != "" {
+		r.mux.ServeHTTP(wrt, req)
+		return
+	}
+
+	if allowed := r.allowedMethods(req); len(allowed) > 0 {
+		wrt.Header().Set("Allow", strings.Join(allowed, ", "))
+		r.execute(wrt, req, r.methodNotAllowed)
+		return
+	}
+
+	r.execute(wrt, req, r.notFound)
+}
+
+func (r *Router) allowedMethods(req *http.Request) []string {
+	allowed := make([]string, 0)
+
+	probe := new(http.Request)
+	*probe = *req
+
+	methods := r.methods.KeysVector().Collect()
+	_, get := r.methods.Get(http.MethodGet)
+	_, head := r.methods.Get(http.MethodHead)
+	if get && !head {
+		methods = append(methods, http.MethodHead)
+	}
+
+	for _, method := range methods {
+		probe.Method = method
+		if _, pattern := r.mux.Handler(probe); pattern != "" {
+			allowed = append(allowed, method)
+		}
+	}
+
+	slices.Sort(allowed)
+
+	return allowed
+}
+
+func defaultNotFound(w http.ResponseWriter, r *http.Request, c *Context) result.Result {
+	return result.TextErr(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+}
+
+func defaultMethodNotAllowed(w http.ResponseWriter, r *http.Request, c *Context) result.Result {
+	return result.TextErr(http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+}
@@ -0,0 +1,187 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+const (
+	FORM_URLENCODED = "application/x-www-form-urlencoded"
+	FORM_MULTIPART  = "multipart/form-data"
+)
+
+const multipartMemory = 32 << 20
+
+// InputForm binds an application/x-www-form-urlencoded request body into
+// a struct of type T.
+//
+// Fields are matched using the `form` tag, or the field name when the tag
+// is not present, and support the same types and tags as InputQuery.
+// Query string values are not considered.
+//
+// If the request is not form encoded, it returns a non-nil *result.Result
+// with status 415 Unsupported Media Type. If the body cannot be read, the
+// status is 422 Unprocessable Entity, and if any field cannot be parsed,
+// 400 Bad Request listing every invalid field. The request body is always
+// closed by this function.
+//
+// Example:
+//
+//	type Login struct {
+//	    User     string `form:"user"`
+//	    Password string `form:"password"`
+//	    Remember bool   `form:"remember"`
+//	}
+//
+//	func handler(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+//	    login, res := router.InputForm[Login](r)
+//	    if res != nil {
+//	        return *res
+//	    }
+//	    return result.JsonOk(authenticate(login))
+//	}
+func InputForm[T any](r *http.Request) (T, *result.Result) {
+	return InputFormWithOpts[T](nil, r, InputOpts{})
+}
+
+// InputFormWithOpts binds an application/x-www-form-urlencoded request body
+// into a struct of type T with additional options.
+//
+// The request body is always closed by this function. Use InputOpts to specify:
+//   - Limit: maximum number of bytes to read (0 means no limit)
+//   - Strict: whether to return an error if the limit is exceeded
+//
+// Errors are reported as in InputForm, with status 413 Request Entity Too
+// Large when the limit is exceeded in strict mode.
+func InputFormWithOpts[T any](w http.ResponseWriter, r *http.Request, opts InputOpts) (T, *result.Result) {
+	var payload T
+
+	if res := expectMedia(r, FORM_URLENCODED); res != nil {
+		r.Body.Close()
+		return payload, res
+	}
+
+	raw, res := InputBytesWithOpts(w, r, opts)
+	if res != nil {
+		return payload, res
+	}
+
+	return formDecode[T](raw)
+}
+
+// InputMultipart binds a multipart/form-data request body into a struct of
+// type T.
+//
+// Form values are bound as in InputForm. Uploaded files are bound into
+// fields of type *multipart.FileHeader (first file of the key) or
+// []*multipart.FileHeader (every file of the key). Temporary files created
+// while parsing are removed by the HTTP server once the request finishes.
+//
+// If the request is not multipart, it returns a non-nil *result.Result
+// with status 415 Unsupported Media Type. If the body is malformed, the
+// status is 422 Unprocessable Entity, and if any field cannot be parsed,
+// 400 Bad Request listing every invalid field.
+//
+// Example:
+//
+//	type Upload struct {
+//	    Title  string                  `form:"title"`
+//	    Cover  *multipart.FileHeader   `form:"cover"`
+//	    Photos []*multipart.FileHeader `form:"photos"`
+//	}
+//
+//	func handler(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+//	    upload, res := router.InputMultipart[Upload](r)
+//	    if res != nil {
+//	        return *res
+//	    }
+//	    file, err := upload.Cover.Open()
+//	    ...
+//	}
+func InputMultipart[T any](r *http.Request) (T, *result.Result) {
+	return InputMultipartWithOpts[T](nil, r, InputOpts{})
+}
+
+// InputMultipartWithOpts binds a multipart/form-data request body into a
+// struct of type T with additional options.
+//
+// Use InputOpts to specify:
+//   - Limit: maximum size of the whole request body (0 means no limit).
+//     Since a truncated multipart body cannot be decoded, the limit is
+//     always enforced strictly.
+//   - FileLimit: maximum size of each uploaded file (0 means no limit).
+//     It is checked once the whole body has been parsed and the files
+//     spooled to memory or disk, so it rejects the request but does not
+//     bound the data read from the client; only Limit does.
+//
+// Errors are reported as in InputMultipart, with status 413 Request Entity
+// Too Large when any of the limits is exceeded.
+func InputMultipartWithOpts[T any](w http.ResponseWriter, r *http.Request, opts InputOpts) (T, *result.Result) {
+	var payload T
+
+	if res := expectMedia(r, FORM_MULTIPART); res != nil {
+		r.Body.Close()
+		return payload, res
+	}
+
+	if res := decompressBody(r); res != nil {
+		return payload, res
+	}
+	defer func() { r.Body.Close() }()
+
+	memory := int64(multipartMemory)
+	if opts.Limit > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, opts.Limit)
+		memory = min(memory, opts.Limit)
+	}
+
+	if err := r.ParseMultipartForm(memory); err != nil {
+		if maxBytes := new(http.MaxBytesError); errors.As(err, &maxBytes) {
+			res := result.ProblemErr(http.StatusRequestEntityTooLarge)
+			return payload, &res
+		}
+		res := result.ProblemErr(http.StatusUnprocessableEntity, err)
+		return payload, &res
+	}
+
+	if opts.FileLimit > 0 {
+		errs := make([]error, 0)
+		for key, files := range r.MultipartForm.File {
+			for _, file := range files {
+				if file.Size > opts.FileLimit {
+					errs = append(errs, fmt.Errorf("%s: file '%s' exceeds %d bytes", key, file.Filename, opts.FileLimit))
+				}
+			}
+		}
+		if len(errs) > 0 {
+			res := result.ProblemErr(http.StatusRequestEntityTooLarge, errs...)
+			return payload, &res
+		}
+	}
+
+	if errs := bindValues(r.MultipartForm.Value, "form", &payload); len(errs) > 0 {
+		res := result.ProblemErr(http.StatusBadRequest, errs...)
+		return payload, &res
+	}
+
+	bindFiles(r.MultipartForm.File, "form", &payload)
+
+	return payload, validate(&payload)
+}
+
+func formDecode[T any](raw []byte) (T, *result.Result) {
+	return decode[T](NewFormDecoder(), raw)
+}
+
+func expectMedia(r *http.Request, expected string) *result.Result {
+	media, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || media != expected {
+		res := result.ProblemErr(http.StatusUnsupportedMediaType, fmt.Errorf("expected content type %s", expected))
+		return &res
+	}
+	return nil
+}
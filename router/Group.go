@@ -0,0 +1,256 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Rafael24595/go-web/router/docs"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+// Group represents a set of routes sharing a common path prefix and
+// handler configuration.
+//
+// A group inherits the base path of the Router and every setting of its
+// parent groups: contextualizers, group contextualizers, middlewares, error
+// and panic handlers, CORS policy and documentation. Settings defined on a group
+// override the inherited ones, and settings defined on a route (through
+// HandlerOptions) override the group ones.
+//
+// Groups are created with Router.Group or Group.Group and configured
+// inside the given callback. Since routes are registered immediately,
+// group settings should be defined before the routes that depend on them.
+type Group struct {
+	router          *Router
+	parent          *Group
+	prefix          string
+	contextualizers []RequestHandler
+	middleware      []Middleware
+	context         *contextHandler
+	error           *errorHandler
+	panic           *panicHandler
+	cors            *Cors
+}
+
+// Group creates a route group under the given prefix and passes it to the
+// configure callback.
+//
+// Example:
+//
+//	router.Group("/users", func(g *router.Group) {
+//	    g.GroupContextualizer(auth)
+//	    g.Route("GET", findUser, "/{%s}", ID)
+//
+//	    g.Group("/admin", func(g *router.Group) {
+//	        g.Route("DELETE", deleteUser, "/{%s}", ID)
+//	    })
+//	})
+//
+// Returns the Router itself for fluent configuration.
+func (r *Router) Group(prefix string, configure func(*Group)) *Router {
+	configure(newGroup(r, nil, prefix))
+	return r
+}
+
+func newGroup(router *Router, parent *Group, prefix string) *Group {
+	return &Group{
+		router:          router,
+		parent:          parent,
+		prefix:          prefix,
+		contextualizers: make([]RequestHandler, 0),
+		middleware:      make([]Middleware, 0),
+	}
+}
+
+// Group creates a nested group under the current group prefix and passes
+// it to the configure callback. The nested group inherits every setting
+// of the current one.
+//
+// Returns the Group itself for fluent configuration.
+func (g *Group) Group(prefix string, configure func(*Group)) *Group {
+	configure(newGroup(g.router, g, prefix))
+	return g
+}
+
+// Prefix returns the full path prefix of the group, including the
+// prefixes of its parents but not the Router base path.
+func (g *Group) Prefix() string {
+	if g.parent == nil {
+		return g.prefix
+	}
+	return g.parent.Prefix() + g.prefix
+}
+
+// Contextualizer sets the context initializer for every route of the group.
+//
+// Returns the Group itself for fluent configuration.
+func (g *Group) Contextualizer(handler contextHandler) *Group {
+	g.context = &handler
+	return g
+}
+
+// GroupContextualizer appends a handler executed before every route of the
+// group, after the contextualizers of its parent groups.
+//
+// If the handler returns an error result, the route handler is not executed
+// and the error is resolved by the error handler.
+//
+// Returns the Group itself for fluent configuration.
+func (g *Group) GroupContextualizer(handler RequestHandler) *Group {
+	g.contextualizers = append(g.contextualizers, handler)
+	return g
+}
+
+// Use appends middlewares executed around every route of the group.
+//
+// Group middlewares run after the middlewares of the Router and the parent
+// groups, and before the group contextualizers. See Middleware for the
+// complete execution order.
+//
+// Returns the Group itself for fluent configuration.
+func (g *Group) Use(middleware ...Middleware) *Group {
+	g.middleware = append(g.middleware, middleware...)
+	return g
+}
+
+// ErrorHandler sets the error handler for every route of the group.
+//
+// Returns the Group itself for fluent configuration.
+func (g *Group) ErrorHandler(handler errorHandler) *Group {
+	g.error = &handler
+	return g
+}
+
+// PanicHandler sets the panic handler for every route of the group.
+//
+// Returns the Group itself for fluent configuration.
+func (g *Group) PanicHandler(handler panicHandler) *Group {
+	g.panic = &handler
+	return g
+}
+
+// Cors sets the CORS policy for every route of the group, overriding the
+// Router policy.
+//
+// Returns the Group itself for fluent configuration.
+func (g *Group) Cors(cors *Cors) *Group {
+	g.cors = cors
+	return g
+}
+
+// Document registers the shared documentation (headers, cookies and
+// responses) of the group in the Router documentation viewer.
+//
+// Returns the Group itself for fluent configuration.
+func (g *Group) Document(doc docs.DocGroup) *Group {
+	path := fmt.Sprintf("%s%s", g.router.basePath, g.Prefix())
+	g.router.docViewer.RegisterGroup(path, doc)
+	return g
+}
+
+// Route registers a basic route inside the group with default handler options.
+//
+// Returns the Group itself for fluent configuration.
+func (g *Group) Route(method string, handler RequestHandler, pattern string, params ...any) *Group {
+	return g.RouteWithOptions(method, NewHandlerOptions(handler), pattern, params...)
+}
+
+// RouteWithOptions registers a basic route inside the group with advanced
+// handler options.
+//
+// Returns the Group itself for fluent configuration.
+func (g *Group) RouteWithOptions(method string, options *HandlerOptions, pattern string, params ...any) *Group {
+	g.router.RouteWithOptions(method, g.options(options), g.pattern(pattern), params...)
+	return g
+}
+
+// RouteDocument registers a documented route inside the group with default
+// handler options.
+//
+// Returns the Group itself for fluent configuration.
+func (g *Group) RouteDocument(method string, handler RequestHandler, pattern string, doc docs.DocRoute) *Group {
+	return g.RouteDocumentWithOptions(method, NewHandlerOptions(handler), pattern, doc)
+}
+
+// RouteDocumentWithOptions registers a documented route inside the group
+// with advanced handler options.
+//
+// Returns the Group itself for fluent configuration.
+func (g *Group) RouteDocumentWithOptions(method string, options *HandlerOptions, pattern string, doc docs.DocRoute) *Group {
+	g.router.RouteDocumentWithOptions(method, g.options(options), g.pattern(pattern), doc)
+	return g
+}
+
+func (g *Group) pattern(pattern string) string {
+	prefix := strings.ReplaceAll(g.Prefix(), "%", "%%")
+	return prefix + pattern
+}
+
+func (g *Group) options(options *HandlerOptions) *HandlerOptions {
+	merged := *options
+
+	if merged.context == nil {
+		merged.context = g.inherit(func(g *Group) bool { return g.context != nil }).context
+	}
+
+	if merged.error == nil {
+		merged.error = g.inherit(func(g *Group) bool { return g.error != nil }).error
+	}
+
+	if merged.panic == nil {
+		merged.panic = g.inherit(func(g *Group) bool { return g.panic != nil }).panic
+	}
+
+	if merged.cors == nil {
+		merged.cors = g.inherit(func(g *Group) bool { return g.cors != nil }).cors
+	}
+
+	handler := wrapMiddleware(options.middleware, options.handler)
+	handler = chainHandlers(g.chain(), handler)
+
+	merged.handler = wrapMiddleware(g.middlewares(), handler)
+	merged.middleware = make([]Middleware, 0)
+
+	return &merged
+}
+
+func (g *Group) inherit(defined func(*Group) bool) *Group {
+	for current := g; current != nil; current = current.parent {
+		if defined(current) {
+			return current
+		}
+	}
+	return &Group{}
+}
+
+func (g *Group) chain() []RequestHandler {
+	chain := make([]RequestHandler, 0)
+	if g.parent != nil {
+		chain = append(chain, g.parent.chain()...)
+	}
+	return append(chain, g.contextualizers...)
+}
+
+func (g *Group) middlewares() []Middleware {
+	middleware := make([]Middleware, 0)
+	if g.parent != nil {
+		middleware = append(middleware, g.parent.middlewares()...)
+	}
+	return append(middleware, g.middleware...)
+}
+
+func chainHandlers(guards []RequestHandler, handler RequestHandler) RequestHandler {
+	if len(guards) == 0 {
+		return handler
+	}
+
+	return func(w http.ResponseWriter, r *http.Request, c *Context) result.Result {
+		for _, guard := range guards {
+			if res := guard(w, r, c); res.Err() {
+				return res
+			}
+		}
+		return handler(w, r, c)
+	}
+}
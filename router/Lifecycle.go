@@ -0,0 +1,266 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Rafael24595/go-web/router/log"
+)
+
+type startHook = func(addr string)
+type shutdownHook = func(ctx context.Context) error
+
+type lifecycle struct {
+	mu       sync.Mutex
+	closing  context.Context
+	cancel   context.CancelFunc
+	servers  []*http.Server
+	onStart  []startHook
+	onStop   []shutdownHook
+	signals  []os.Signal
+	timeout  time.Duration
+	watch    sync.Once
+	stop     sync.Once
+	stopped  chan struct{}
+	closed   bool
+	shutdown error
+}
+
+// shutdownKey holds, in the base context of the Router servers, a context
+// that is canceled as soon as Shutdown starts.
+type shutdownKey struct{}
+
+func newLifecycle() *lifecycle {
+	closing, cancel := context.WithCancel(context.Background())
+	return &lifecycle{
+		closing: closing,
+		cancel:  cancel,
+		servers: make([]*http.Server, 0),
+		onStart: make([]startHook, 0),
+		onStop:  make([]shutdownHook, 0),
+		signals: make([]os.Signal, 0),
+		stopped: make(chan struct{}),
+	}
+}
+
+// OnStart registers a hook executed each time one of the Router's servers
+// starts accepting connections.
+//
+// The hook receives the address the server is bound to. Since ListenWithTLS
+// starts two servers, its hooks are executed once per server.
+//
+// Returns the Router itself for fluent configuration.
+func (r *Router) OnStart(hook startHook) *Router {
+	r.lifecycle.mu.Lock()
+	defer r.lifecycle.mu.Unlock()
+
+	r.lifecycle.onStart = append(r.lifecycle.onStart, hook)
+	return r
+}
+
+// OnShutdown registers a hook executed once all the Router's servers have
+// been drained during Shutdown.
+//
+// Hooks run in registration order and receive the shutdown context, so
+// they should honor its deadline. Their errors are joined into the error
+// returned by Shutdown.
+//
+// Returns the Router itself for fluent configuration.
+func (r *Router) OnShutdown(hook shutdownHook) *Router {
+	r.lifecycle.mu.Lock()
+	defer r.lifecycle.mu.Unlock()
+
+	r.lifecycle.onStop = append(r.lifecycle.onStop, hook)
+	return r
+}
+
+// GracefulShutdown enables automatic shutdown when the process receives
+// one of the given signals. If no signals are provided, SIGINT and SIGTERM
+// are used.
+//
+// When a signal arrives, Shutdown is invoked with a context bounded by the
+// given drain timeout. A timeout of 0 waits indefinitely for in-flight
+// requests to complete.
+//
+// Returns the Router itself for fluent configuration.
+func (r *Router) GracefulShutdown(timeout time.Duration, signals ...os.Signal) *Router {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	r.lifecycle.mu.Lock()
+	defer r.lifecycle.mu.Unlock()
+
+	r.lifecycle.timeout = timeout
+	r.lifecycle.signals = signals
+	return r
+}
+
+// Serve accepts incoming connections on the given listener.
+//
+// It behaves like Listen, but lets the caller own the listener, which is
+// useful for tests (e.g. binding to port 0) or socket activation.
+func (r *Router) Serve(listener net.Listener) error {
+	server := r.newServer(listener.Addr().String(), r)
+
+	r.logger.Messagef("The app is listen at: %s", listener.Addr())
+	return r.serve(server, listener, func() error {
+		return server.Serve(listener)
+	})
+}
+
+// Shutdown gracefully stops every server started by the Router.
+//
+// Listeners are closed immediately and in-flight requests are drained until
+// they complete or the context expires. Long-lived responses are ended
+// right away: the request context of streamed results is canceled and
+// WebSocket connections are closed with a going away status. Once the
+// servers are stopped, the hooks registered with OnShutdown are executed
+// and the default logger created by the Router is flushed and closed.
+// Loggers set with Logger are owned by the caller and left open.
+//
+// Shutdown is idempotent: subsequent calls wait for the first one to finish
+// and return its result.
+func (r *Router) Shutdown(ctx context.Context) error {
+	l := r.lifecycle
+
+	l.stop.Do(func() {
+		l.mu.Lock()
+		l.closed = true
+		servers := l.servers
+		hooks := l.onStop
+		l.servers = make([]*http.Server, 0)
+		l.mu.Unlock()
+
+		l.cancel()
+
+		errs := make([]error, 0)
+		for _, server := range servers {
+			if err := server.Shutdown(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		for _, hook := range hooks {
+			if err := hook(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if flusher, ok := r.logger.(log.Flusher); ok && r.ownsLogger {
+			if err := flusher.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		l.shutdown = errors.Join(errs...)
+		close(l.stopped)
+	})
+
+	<-l.stopped
+	return l.shutdown
+}
+
+func (l *lifecycle) baseContext(net.Listener) context.Context {
+	return context.WithValue(context.Background(), shutdownKey{}, l.closing)
+}
+
+// shutdownContext returns the context canceled when the server handling
+// the request shuts down, or nil if it was not started by a Router.
+func shutdownContext(req *http.Request) context.Context {
+	closing, _ := req.Context().Value(shutdownKey{}).(context.Context)
+	return closing
+}
+
+// untilShutdown returns the request with a context that is also canceled
+// when its server shuts down, so long-lived handlers end instead of
+// holding the drain.
+func untilShutdown(req *http.Request) (*http.Request, context.CancelFunc) {
+	closing := shutdownContext(req)
+	if closing == nil {
+		return req, func() {}
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	stop := context.AfterFunc(closing, cancel)
+
+	return req.WithContext(ctx), func() {
+		stop()
+		cancel()
+	}
+}
+
+func (r *Router) serve(server *http.Server, listener net.Listener, serve func() error) error {
+	l := r.lifecycle
+
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		listener.Close()
+		return nil
+	}
+	l.servers = append(l.servers, server)
+	hooks := l.onStart
+	l.mu.Unlock()
+
+	r.watchSignals()
+
+	for _, hook := range hooks {
+		hook(listener.Addr().String())
+	}
+
+	err := serve()
+	if !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	<-l.stopped
+	return nil
+}
+
+func (r *Router) watchSignals() {
+	l := r.lifecycle
+
+	l.mu.Lock()
+	signals := l.signals
+	timeout := l.timeout
+	l.mu.Unlock()
+
+	if len(signals) == 0 {
+		return
+	}
+
+	l.watch.Do(func() {
+		channel := make(chan os.Signal, 1)
+		signal.Notify(channel, signals...)
+
+		go func() {
+			defer signal.Stop(channel)
+
+			select {
+			case sig := <-channel:
+				r.logger.Messagef("Signal %s received, shutting down", sig)
+			case <-l.stopped:
+				return
+			}
+
+			ctx := context.Background()
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+
+			if err := r.Shutdown(ctx); err != nil {
+				r.logger.Error(err)
+			}
+		}()
+	})
+}
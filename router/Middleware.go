@@ -7,6 +7,36 @@ import (
 	"strings"
 )
 
+// Middleware wraps a RequestHandler to add behavior around the route
+// execution.
+//
+// A middleware receives the next handler in the chain and returns a new
+// handler. It runs after the request Context has been initialized, so it
+// can read and enrich it, short-circuit the request by returning its own
+// result.Result, or inspect and transform the result returned by next.
+//
+// Middlewares can be attached globally (Router.Use), per group (Group.Use)
+// and per route (HandlerOptions.Use). They are executed in the following
+// deterministic order, each level in registration order:
+//
+//  1. Router middlewares
+//  2. Group middlewares, parent groups first
+//  3. Group contextualizers, parent groups first
+//  4. Route middlewares
+//  5. Route handler
+//
+// Example:
+//
+//	func Timing(next router.RequestHandler) router.RequestHandler {
+//	    return func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+//	        start := time.Now()
+//	        res := next(w, r, ctx)
+//	        w.Header().Set("Server-Timing", fmt.Sprintf("app;dur=%d", time.Since(start).Milliseconds()))
+//	        return res
+//	    }
+//	}
+type Middleware = func(next RequestHandler) RequestHandler
+
 type middleware func(w http.ResponseWriter, req *http.Request) bool
 
 func corsMiddleware(cors Cors) middleware {
@@ -61,3 +91,10 @@ func applyMiddleware(next http.Handler, handlers []middleware) http.Handler {
 		next.ServeHTTP(w, req)
 	})
 }
+
+func wrapMiddleware(middleware []Middleware, handler RequestHandler) RequestHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
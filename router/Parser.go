@@ -0,0 +1,86 @@
+package router
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// parseText converts a raw string into a value of the given type.
+//
+// Supported types are strings, booleans, integers, unsigned integers,
+// floats, time.Duration, time.Time (RFC 3339, or the given layout) and
+// any type implementing encoding.TextUnmarshaler.
+func parseText(raw string, t reflect.Type, layout string) (reflect.Value, error) {
+	value := reflect.New(t).Elem()
+
+	switch t {
+	case durationType:
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return value, errors.New("expected a duration")
+		}
+		value.SetInt(int64(duration))
+		return value, nil
+	case timeType:
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		date, err := time.Parse(layout, raw)
+		if err != nil {
+			return value, fmt.Errorf("expected a time with layout %s", layout)
+		}
+		value.Set(reflect.ValueOf(date))
+		return value, nil
+	}
+
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		unmarshaler := value.Addr().Interface().(encoding.TextUnmarshaler)
+		if err := unmarshaler.UnmarshalText([]byte(raw)); err != nil {
+			return value, err
+		}
+		return value, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return value, errors.New("expected a boolean")
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, t.Bits())
+		if err != nil {
+			return value, errors.New("expected an integer")
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(raw, 10, t.Bits())
+		if err != nil {
+			return value, errors.New("expected an unsigned integer")
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, t.Bits())
+		if err != nil {
+			return value, errors.New("expected a number")
+		}
+		value.SetFloat(parsed)
+	default:
+		return value, fmt.Errorf("unsupported type %s", t)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,127 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+// Path reads the path parameter with the given name and parses it into
+// a value of type T.
+//
+// Supported types are strings, booleans, integers, unsigned integers,
+// floats, time.Duration, time.Time (RFC 3339) and any type implementing
+// encoding.TextUnmarshaler.
+//
+// If the parameter is missing or cannot be parsed, it returns the zero
+// value of T and a non-nil *result.Result with status 400 Bad Request.
+//
+// Use docs.TypedParameter with the same type to document the parameter.
+//
+// Example:
+//
+//	func handler(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+//	    page, res := router.Path[uint](r, "page")
+//	    if res != nil {
+//	        return *res
+//	    }
+//	    return result.JsonOk(findPage(page))
+//	}
+func Path[T any](r *http.Request, name string) (T, *result.Result) {
+	var zero T
+
+	raw := r.PathValue(name)
+	if raw == "" {
+		return zero, pathError(name, errors.New("the parameter is required"))
+	}
+
+	value, err := parseText(raw, reflect.TypeFor[T](), "")
+	if err != nil {
+		return zero, pathError(name, err)
+	}
+
+	return value.Interface().(T), nil
+}
+
+// PathString reads the path parameter with the given name as a non-empty string.
+//
+// If the parameter is missing, it returns an empty string and a non-nil
+// *result.Result with status 400 Bad Request.
+func PathString(r *http.Request, name string) (string, *result.Result) {
+	return Path[string](r, name)
+}
+
+// PathInt reads the path parameter with the given name as an int.
+//
+// If the parameter is missing or is not a valid integer, it returns 0 and
+// a non-nil *result.Result with status 400 Bad Request.
+//
+// Use docs.ParameterInt to document the parameter.
+func PathInt(r *http.Request, name string) (int, *result.Result) {
+	return Path[int](r, name)
+}
+
+// PathInt64 reads the path parameter with the given name as an int64.
+//
+// If the parameter is missing or is not a valid integer, it returns 0 and
+// a non-nil *result.Result with status 400 Bad Request.
+//
+// Use docs.ParameterInt to document the parameter.
+func PathInt64(r *http.Request, name string) (int64, *result.Result) {
+	return Path[int64](r, name)
+}
+
+// PathUUID reads the path parameter with the given name as a UUID in its
+// canonical textual form (8-4-4-4-12 hexadecimal digits).
+//
+// The UUID is returned in lower case. If the parameter is missing or is not
+// a valid UUID, it returns an empty string and a non-nil *result.Result
+// with status 400 Bad Request.
+//
+// Use docs.ParameterUUID to document the parameter.
+func PathUUID(r *http.Request, name string) (string, *result.Result) {
+	raw, res := Path[string](r, name)
+	if res != nil {
+		return raw, res
+	}
+
+	if !isUUID(raw) {
+		return "", pathError(name, errors.New("expected a UUID"))
+	}
+
+	return strings.ToLower(raw), nil
+}
+
+func isUUID(raw string) bool {
+	if len(raw) != 36 {
+		return false
+	}
+
+	for i, c := range raw {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !isHex(c) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func isHex(c rune) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+func pathError(name string, err error) *result.Result {
+	res := result.ProblemErr(http.StatusBadRequest, fmt.Errorf("invalid path parameter '%s': %w", name, err))
+	return &res
+}
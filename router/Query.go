@@ -0,0 +1,49 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+// InputQuery binds the request query string into a struct of type T.
+//
+// Fields are matched using the `query` tag, or the field name when the tag
+// is not present. Slices take every value of a repeated key, pointers stay
+// nil when the key is missing, and the `default` tag provides a fallback
+// value. Supported field types are the ones supported by Path, including
+// time.Duration, time.Time (RFC 3339, or the layout defined by the `layout`
+// tag) and encoding.TextUnmarshaler implementations.
+//
+// If any field cannot be parsed, it returns the partially bound value and
+// a non-nil *result.Result with status 400 Bad Request listing every
+// invalid field. The bound value is then checked against its `validate`
+// tags, returning 422 Unprocessable Entity on failure.
+//
+// Example:
+//
+//	type Search struct {
+//	    Term  string        `query:"q"`
+//	    Tags  []string      `query:"tag"`
+//	    Page  int           `query:"page" default:"1"`
+//	    Since *time.Time    `query:"since" layout:"2006-01-02"`
+//	    TTL   time.Duration `query:"ttl" default:"1m"`
+//	}
+//
+//	func handler(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+//	    search, res := router.InputQuery[Search](r)
+//	    if res != nil {
+//	        return *res
+//	    }
+//	    return result.JsonOk(find(search))
+//	}
+func InputQuery[T any](r *http.Request) (T, *result.Result) {
+	var payload T
+
+	if errs := bindValues(r.URL.Query(), "query", &payload); len(errs) > 0 {
+		res := result.ProblemErr(http.StatusBadRequest, errs...)
+		return payload, &res
+	}
+
+	return payload, validate(&payload)
+}
@@ -0,0 +1,153 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"sync"
+
+	"golang.org/x/net/html/charset"
+)
+
+// RequestDecoder defines the interface for deserializing a request body
+// in a specific format (JSON, XML, form, etc.) into a payload.
+//
+// It is the input counterpart of result.ResultEncoder. Decoders are
+// registered by media type with RegisterDecoder and selected by Input
+// according to the request Content-Type.
+type RequestDecoder interface {
+	Decode(raw []byte, payload any) error
+}
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = []registeredDecoder{
+		{media: "application/json", decoder: NewJsonDecoder()},
+		{media: "application/xml", decoder: NewXmlDecoder()},
+		{media: "text/xml", decoder: NewXmlDecoder()},
+		{media: FORM_URLENCODED, decoder: NewFormDecoder()},
+	}
+)
+
+type registeredDecoder struct {
+	media   string
+	decoder RequestDecoder
+}
+
+// RegisterDecoder registers a decoder for the given media type, replacing
+// any decoder previously registered for it.
+//
+// Example:
+//
+//	router.RegisterDecoder("application/yaml", &YamlDecoder{})
+func RegisterDecoder(media string, decoder RequestDecoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+
+	for i, v := range decoders {
+		if v.media == media {
+			decoders[i].decoder = decoder
+			return
+		}
+	}
+
+	decoders = append(decoders, registeredDecoder{
+		media:   media,
+		decoder: decoder,
+	})
+}
+
+// Decoders returns the media types with a registered decoder, in
+// registration order.
+func Decoders() []string {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+
+	media := make([]string, len(decoders))
+	for i, v := range decoders {
+		media[i] = v.media
+	}
+	return media
+}
+
+func findDecoder(media string) (RequestDecoder, bool) {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+
+	for _, v := range decoders {
+		if v.media == media {
+			return v.decoder, true
+		}
+	}
+	return nil, false
+}
+
+type jsonDecoder struct{}
+
+// NewJsonDecoder creates a new JSON decoder.
+func NewJsonDecoder() RequestDecoder {
+	return &jsonDecoder{}
+}
+
+// Decode parses the raw JSON body into the payload.
+func (d *jsonDecoder) Decode(raw []byte, payload any) error {
+	return json.Unmarshal(raw, payload)
+}
+
+type xmlDecoder struct{}
+
+// NewXmlDecoder creates a new XML decoder.
+//
+// The decoder supports multiple character sets via
+// golang.org/x/net/html/charset.
+func NewXmlDecoder() RequestDecoder {
+	return &xmlDecoder{}
+}
+
+// Decode parses the raw XML body into the payload.
+func (d *xmlDecoder) Decode(raw []byte, payload any) error {
+	decoder := xml.NewDecoder(bytes.NewReader(raw))
+	decoder.CharsetReader = charset.NewReaderLabel
+	return decoder.Decode(payload)
+}
+
+type formDecoder struct{}
+
+// NewFormDecoder creates a new application/x-www-form-urlencoded decoder.
+//
+// The payload must be a pointer to a struct, whose fields are bound using
+// the `form` tag as described in InputForm.
+func NewFormDecoder() RequestDecoder {
+	return &formDecoder{}
+}
+
+// Decode parses the raw form body into the payload.
+func (d *formDecoder) Decode(raw []byte, payload any) error {
+	values, err := url.ParseQuery(string(raw))
+	if err != nil {
+		return err
+	}
+
+	if errs := bindValues(values, "form", payload); len(errs) > 0 {
+		return &BindError{Errors: errs}
+	}
+
+	return nil
+}
+
+// BindError reports the fields that could not be bound while decoding
+// a request. Decoders may return it to produce a 400 Bad Request
+// result listing every invalid field.
+type BindError struct {
+	Errors []error
+}
+
+func (e *BindError) Error() string {
+	return errors.Join(e.Errors...).Error()
+}
+
+func (e *BindError) Unwrap() []error {
+	return e.Errors
+}
@@ -1,16 +1,22 @@
 package router
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	stdlog "log"
+	"io/fs"
+	"log/slog"
+	"net"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Rafael24595/go-collections/collection"
 	"github.com/Rafael24595/go-web/router/configuration"
 	"github.com/Rafael24595/go-web/router/docs"
 	"github.com/Rafael24595/go-web/router/log"
 	"github.com/Rafael24595/go-web/router/result"
+	"github.com/Rafael24595/go-web/router/websocket"
 )
 
 type contextHandler = func(http.ResponseWriter, *http.Request) (*Context, error)
@@ -26,21 +32,26 @@ const BASE = "$BASE"
 //   - request context initialization
 //   - error handling
 //   - panic recovery
+//   - route-specific CORS policy
+//   - route-specific middlewares
 //
 // Use NewHandlerOptions to create a new instance, and the builder-style
-// methods (Context, Error, Panic) to configure it.
+// methods (Context, Error, Panic, Cors, Use) to configure it.
 type HandlerOptions struct {
-	handler RequestHandler
-	context *contextHandler
-	error   *errorHandler
-	panic   *panicHandler
+	handler    RequestHandler
+	context    *contextHandler
+	error      *errorHandler
+	panic      *panicHandler
+	cors       *Cors
+	middleware []Middleware
 }
 
 // NewHandlerOptions creates a new HandlerOptions instance for the given
 // route handler. By default, no context, error, or panic handlers are set.
 func NewHandlerOptions(handler RequestHandler) *HandlerOptions {
 	return &HandlerOptions{
-		handler: handler,
+		handler:    handler,
+		middleware: make([]Middleware, 0),
 	}
 }
 
@@ -76,16 +87,46 @@ func (h *HandlerOptions) Panic(panic *panicHandler) *HandlerOptions {
 	return h
 }
 
+// Cors sets a CORS policy for the route, overriding the Router policy.
+//
+// Returns the HandlerOptions itself for fluent configuration.
+func (h *HandlerOptions) Cors(cors *Cors) *HandlerOptions {
+	h.cors = cors
+	return h
+}
+
+// Use appends middlewares executed around the route handler.
+//
+// Route middlewares are the innermost ones: they run after the Router and
+// group middlewares and the group contextualizers.
+//
+// Returns the HandlerOptions itself for fluent configuration.
+func (h *HandlerOptions) Use(middleware ...Middleware) *HandlerOptions {
+	h.middleware = append(h.middleware, middleware...)
+	return h
+}
+
 type Router struct {
 	logger               log.Log
+	ownsLogger           bool
 	contextualizer       collection.IDictionary[string, contextHandler]
 	groupContextualizers collection.IDictionary[string, collection.Vector[RequestHandler]]
 	errors               collection.IDictionary[string, errorHandler]
 	panics               collection.IDictionary[string, panicHandler]
 	routes               collection.IDictionary[string, RequestHandler]
+	middleware           []Middleware
 	basePath             string
 	cors                 *Cors
+	corsRoutes           collection.IDictionary[string, *Cors]
+	compression          *Compression
+	accessLog            *AccessLog
 	docViewer            docs.IDocViewer
+	mux                  *http.ServeMux
+	methods              collection.IDictionary[string, bool]
+	notFound             RequestHandler
+	methodNotAllowed     RequestHandler
+	lifecycle            *lifecycle
+	server               ServerOptions
 }
 
 // NewRouter creates and initializes a new Router instance with sensible defaults.
@@ -96,18 +137,30 @@ type Router struct {
 //   - an empty base path
 //   - default CORS configuration
 //   - a "no-op" documentation viewer
+//   - a dedicated HTTP mux, isolated from http.DefaultServeMux
+//   - server timeouts and limits read from the global configuration
 //
 // Use this function as the entry point to build and configure a new Router.
 func NewRouter() *Router {
 	return &Router{
 		logger:               log.DefaultLogger(),
+		ownsLogger:           true,
 		contextualizer:       collection.DictionaryEmpty[string, contextHandler](),
 		groupContextualizers: collection.DictionaryEmpty[string, collection.Vector[RequestHandler]](),
 		errors:               collection.DictionaryEmpty[string, errorHandler](),
+		panics:               collection.DictionaryEmpty[string, panicHandler](),
 		routes:               collection.DictionaryEmpty[string, RequestHandler](),
+		middleware:           make([]Middleware, 0),
 		basePath:             "",
 		cors:                 EmptyCors(),
+		corsRoutes:           collection.DictionaryEmpty[string, *Cors](),
 		docViewer:            docs.VoidViewer(),
+		mux:                  http.NewServeMux(),
+		methods:              collection.DictionaryEmpty[string, bool](),
+		notFound:             defaultNotFound,
+		methodNotAllowed:     defaultMethodNotAllowed,
+		lifecycle:            newLifecycle(),
+		server:               DefaultServerOptions(),
 	}
 }
 
@@ -115,25 +168,36 @@ func NewRouter() *Router {
 // error reporting, and panic recovery.
 //
 // By default, a simple logger is provided. This method allows injecting
-// a custom logger implementation.
+// a custom logger implementation, such as log.NewSlogLogger or an existing
+// *slog.Logger adapted with log.SlogLogger.
+//
+// The caller keeps the ownership of the logger, which may be shared by
+// several routers and viewers: Shutdown only closes the default logger
+// created by the Router, so a log.Flusher set here must be closed by the
+// caller once nothing uses it.
 //
 // Returns the Router itself for fluent configuration.
 func (r *Router) Logger(logger log.Log) *Router {
+	if flusher, ok := r.logger.(log.Flusher); ok && r.ownsLogger {
+		flusher.Close()
+	}
+
 	r.logger = logger
+	r.ownsLogger = false
 	return r
 }
 
 // DocViewer registers a documentation viewer responsible for exposing
 // documentation endpoints.
 //
-// When set, the viewer’s handlers are mounted in the underlying HTTP mux,
+// When set, the viewer’s handlers are mounted in the Router's HTTP mux,
 // and new routes will automatically register themselves in the viewer.
 //
 // Returns the Router itself for fluent configuration.
 func (r *Router) DocViewer(viewer docs.IDocViewer) *Router {
 	for _, v := range viewer.Handlers() {
 		pattern := fmt.Sprintf("%s %s", v.Method, v.Route)
-		http.HandleFunc(pattern, v.Handler)
+		r.handle(pattern, http.HandlerFunc(v.Handler))
 	}
 	r.docViewer = viewer
 	return r
@@ -159,7 +223,19 @@ func (r *Router) BasePath(basePath string) *Router {
 func (r *Router) ResourcesPath(path string) *Router {
 	fs := http.FileServer(http.Dir(path))
 	route := fmt.Sprintf("/%s/", path)
-	http.Handle(fmt.Sprintf("GET %s", route), http.StripPrefix(route, fs))
+	r.handle(fmt.Sprintf("GET %s", route), http.StripPrefix(route, fs))
+	return r
+}
+
+// Use appends global middlewares executed around every route handler.
+//
+// Router middlewares are the outermost ones and apply to every route,
+// including the ones registered before the call. See Middleware for the
+// complete execution order.
+//
+// Returns the Router itself for fluent configuration.
+func (r *Router) Use(middleware ...Middleware) *Router {
+	r.middleware = append(r.middleware, middleware...)
 	return r
 }
 
@@ -311,8 +387,12 @@ func (r *Router) route(method string, pattern string, options *HandlerOptions, d
 		r.panics.Put(route, *options.panic)
 	}
 
-	r.routes.Put(route, options.handler)
-	http.HandleFunc(route, r.handler)
+	if options != nil && options.cors != nil {
+		r.corsRoutes.Put(route, options.cors)
+	}
+
+	r.routes.Put(route, wrapMiddleware(options.middleware, options.handler))
+	r.handle(route, http.HandlerFunc(r.handler))
 
 	doc.Method = method
 	doc.BasePath = r.basePath
@@ -336,6 +416,9 @@ func (r *Router) Cors(cors *Cors) *Router {
 
 // Listen starts an HTTP server on the given host.
 //
+// The call blocks until the server fails or the Router is shut down. After
+// a Shutdown, it waits for the shutdown to complete and returns nil.
+//
 // Example:
 //
 //	router.Listen(8080)
@@ -343,31 +426,21 @@ func (r *Router) Cors(cors *Cors) *Router {
 // CORS and other startup middlewares are automatically applied.
 func (r *Router) Listen(host int) error {
 	port := fmt.Sprintf(":%d", host)
-	
-	middleware := make([]middleware, 0)
-	if r.cors.IsNotEmpty() {
-		middleware = append(middleware, corsMiddleware(*r.cors))
-	}
-	
-	return r.listen(port, middleware)
+	return r.listen(port, make([]middleware, 0))
 }
 
 // ListenTLS starts an HTTPS server on the given host with TLS enabled.
 //
-// Requires a certificate and private key.
+// Requires a certificate and private key, unless the ServerOptions define
+// a TLSConfig that already provides the certificates (Certificates or
+// GetCertificate), in which case both may be empty.
 //
 // Example:
 //
 //	router.ListenTLS(8443, "server.crt", "server.key")
 func (r *Router) ListenTLS(hostTLS int, certTLS, keyTLS string) error {
 	portTLS := fmt.Sprintf(":%d", hostTLS)
-
-	middleware := make([]middleware, 0)
-	if r.cors.IsNotEmpty() {
-		middleware = append(middleware, corsMiddleware(*r.cors))
-	}
-
-	return r.listenTLS(portTLS, certTLS, keyTLS, middleware)
+	return r.listenTLS(portTLS, certTLS, keyTLS, make([]middleware, 0))
 }
 
 // ListenWithTLS starts both HTTP and HTTPS servers in parallel.
@@ -383,10 +456,6 @@ func (r *Router) ListenWithTLS(host, hostTLS int, certTLS, keyTLS string) error
 	middleware := make([]middleware, 0)
 	middleware = append(middleware, httpsRedirectMiddleware(hostTLS))
 
-	if r.cors.IsNotEmpty() {
-		middleware = append(middleware, corsMiddleware(*r.cors))
-	}
-
 	go func() {
 		port := fmt.Sprintf(":%d", host)
 		if err := r.listen(port, middleware); err != nil {
@@ -398,25 +467,31 @@ func (r *Router) ListenWithTLS(host, hostTLS int, certTLS, keyTLS string) error
 }
 
 func (r *Router) listenTLS(hostTLS, certTLS, keyTLS string, middleware []middleware) error {
-	server := &http.Server{
-		Addr:     hostTLS,
-		Handler:  applyMiddleware(http.DefaultServeMux, middleware),
-		ErrorLog: stdlog.New(r.logger, "", 0),
+	server := r.newServer(hostTLS, applyMiddleware(r, middleware))
+
+	listener, err := net.Listen("tcp", hostTLS)
+	if err != nil {
+		return err
 	}
 
 	r.logger.Messagef("The app is listen at: %s with TLS", hostTLS)
-	return server.ListenAndServeTLS(certTLS, keyTLS)
+	return r.serve(server, listener, func() error {
+		return server.ServeTLS(listener, certTLS, keyTLS)
+	})
 }
 
 func (r *Router) listen(host string, middleware []middleware) error {
-	server := &http.Server{
-		Addr:     host,
-		Handler:  applyMiddleware(http.DefaultServeMux, middleware),
-		ErrorLog: stdlog.New(r.logger, "", 0),
+	server := r.newServer(host, applyMiddleware(r, middleware))
+
+	listener, err := net.Listen("tcp", host)
+	if err != nil {
+		return err
 	}
 
 	r.logger.Messagef("The app is listen at: %s", host)
-	return server.ListenAndServe()
+	return r.serve(server, listener, func() error {
+		return server.Serve(listener)
+	})
 }
 
 // ViewerSources retrieves the list of documentation sources currently
@@ -440,7 +515,77 @@ func (r *Router) ViewerSources() []docs.DocViewerSources {
 	return sources
 }
 
+// ServeHTTP implements http.Handler, dispatching the request through the
+// Router's own mux after applying the configured CORS policy.
+//
+// This allows mounting the Router inside any http.Server, composing several
+// routers in the same process, or testing with httptest.NewServer(router).
+func (r *Router) ServeHTTP(wrt http.ResponseWriter, req *http.Request) {
+	if r.accessLog != nil {
+		writer := &accessWriter{ResponseWriter: wrt}
+		start := time.Now()
+		defer func() {
+			_, pattern := r.mux.Handler(req)
+			r.accessLog.record(r.logger, writer, req, pattern, start)
+		}()
+		wrt = writer
+	}
+
+	middleware := make([]middleware, 0)
+	if cors := r.resolveCors(req); cors.IsNotEmpty() {
+		middleware = append(middleware, corsMiddleware(*cors))
+	}
+
+	if r.compression != nil && !websocket.IsUpgrade(req) {
+		if encoding, ok := r.compression.negotiate(req.Header.Get("Accept-Encoding")); ok {
+			writer := newCompressWriter(wrt, req, r.compression, encoding)
+			defer func() {
+				if err := writer.Close(); err != nil {
+					r.logger.Errorf("Error compressing response: %s", err.Error())
+				}
+			}()
+			wrt = writer
+		}
+	}
+
+	applyMiddleware(http.HandlerFunc(r.dispatch), middleware).ServeHTTP(wrt, req)
+}
+
+func (r *Router) resolveCors(req *http.Request) *Cors {
+	probe := req
+	if method := req.Header.Get("Access-Control-Request-Method"); req.Method == http.MethodOptions && method != "" {
+		probe = new(http.Request)
+		*probe = *req
+		probe.Method = method
+	}
+
+	if _, pattern := r.mux.Handler(probe); pattern != "" {
+		if cors, ok := r.corsRoutes.Get(pattern); ok {
+			return cors
+		}
+	}
+
+	return r.cors
+}
+
+func (r *Router) handle(pattern string, handler http.Handler) {
+	if method, _, ok := strings.Cut(pattern, " "); ok {
+		r.methods.Put(method, true)
+	}
+	r.mux.Handle(pattern, handler)
+}
+
 func (r *Router) handler(wrt http.ResponseWriter, req *http.Request) {
+	handler, ok := r.routes.Get(req.Pattern)
+	if !ok {
+		r.logger.Errors("Request handler not found")
+		handler = r.notFound
+	}
+
+	r.execute(wrt, req, handler)
+}
+
+func (r *Router) execute(wrt http.ResponseWriter, req *http.Request, handler RequestHandler) {
 	defer func() {
 		if rec := recover(); rec != nil {
 			r.managePanic(wrt, req, rec)
@@ -450,12 +595,12 @@ func (r *Router) handler(wrt http.ResponseWriter, req *http.Request) {
 	config := configuration.Instance()
 	if config.Dev() && config.TraceRequest() {
 		message := fmt.Sprintf("%s - %s", req.RemoteAddr, req.Pattern)
-		r.logger.Custom("DEV-REQUEST", message)
-	}
-
-	handler, ok := r.routes.Get(req.Pattern)
-	if !ok {
-		r.logger.Errors("Request handler not found")
+		log.Attrs(r.logger, "DEV-REQUEST", message,
+			slog.String(log.METHOD, req.Method),
+			slog.String(log.ROUTE, req.Pattern),
+			slog.String(log.PATH, req.URL.Path),
+			slog.String(log.REMOTE, req.RemoteAddr),
+			slog.String(log.REQUEST_ID, req.Header.Get("X-Request-Id")))
 	}
 
 	ctx, ctxResult := r.initializeContext(wrt, req)
@@ -464,13 +609,17 @@ func (r *Router) handler(wrt http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	result := handler(wrt, req, ctx)
+	result := wrapMiddleware(r.middleware, handler)(wrt, req, ctx)
 	if result.Ignore() {
 		return
 	}
 
+	if result.Ok() && result.Negotiable() {
+		result = r.negotiate(wrt, req, result)
+	}
+
 	if result.Ok() {
-		r.manageOk(wrt, req, result)
+		r.manageOk(wrt, req, ctx, result)
 		return
 	}
 
@@ -496,7 +645,11 @@ func (r *Router) initializeContext(wrt http.ResponseWriter, req *http.Request) (
 		ctx = NewContext()
 	}
 
-	group := strings.Split(req.Pattern, " ")[1]
+	_, group, ok := strings.Cut(req.Pattern, " ")
+	if !ok {
+		return ctx, nil
+	}
+
 	keys := r.groupContextualizers.KeysVector().Filter(func(key string) bool {
 		return strings.HasPrefix(group, key)
 	})
@@ -519,7 +672,15 @@ func (r *Router) initializeContext(wrt http.ResponseWriter, req *http.Request) (
 	return ctx, nil
 }
 
-func (r *Router) manageOk(wrt http.ResponseWriter, req *http.Request, result result.Result) {
+func (r *Router) manageOk(wrt http.ResponseWriter, req *http.Request, ctx *Context, result result.Result) {
+	if stream := result.Streamer(); stream != nil {
+		if result.Ok() && r.conditional(wrt, req, ctx, result) {
+			return
+		}
+		r.writeStream(wrt, req, result, stream)
+		return
+	}
+
 	encoder := result.Encoder()
 	encode, err := encoder.Encode(result.Payload())
 	if err != nil {
@@ -528,21 +689,23 @@ func (r *Router) manageOk(wrt http.ResponseWriter, req *http.Request, result res
 	}
 
 	if result.Err() {
-		http.Error(wrt, string(encode), result.Status())
+		r.writeErr(wrt, result, encode)
 		return
 	}
 
 	if result.File() {
-		http.ServeFile(wrt, req, string(encode))
+		r.writeFile(wrt, req, ctx, result, encode)
 		return
 	}
 
-	wrt.WriteHeader(result.Status())
-
-	for k, v := range encoder.Headers() {
-		wrt.Header().Set(k, v)
+	result = result.ResolveETag(encode)
+	if r.conditional(wrt, req, ctx, result) {
+		return
 	}
 
+	r.writeHeaders(wrt, result, encoder.Headers())
+	wrt.WriteHeader(result.Status())
+
 	_, err = wrt.Write(encode)
 	if err != nil {
 		r.logger.Errorf("Error writing response: %s", err.Error())
@@ -550,6 +713,104 @@ func (r *Router) manageOk(wrt http.ResponseWriter, req *http.Request, result res
 	}
 }
 
+func (r *Router) negotiate(wrt http.ResponseWriter, req *http.Request, res result.Result) result.Result {
+	wrt.Header().Add("Vary", "Accept")
+
+	negotiated, ok := res.Resolve(req.Header.Get("Accept"))
+	if !ok {
+		return result.ProblemErr(http.StatusNotAcceptable,
+			fmt.Errorf("available media types: %s", strings.Join(result.Encoders(), ", ")))
+	}
+
+	return negotiated
+}
+
+// writeStream sends the headers right away and lets the stream write the
+// body. The server write deadline is lifted since streams are long-lived,
+// and their context is canceled when the server shuts down.
+func (r *Router) writeStream(wrt http.ResponseWriter, req *http.Request, res result.Result, stream result.StreamWriter) {
+	req, stop := untilShutdown(req)
+	defer stop()
+
+	r.writeHeaders(wrt, res, res.Encoder().Headers())
+	wrt.WriteHeader(res.Status())
+
+	controller := http.NewResponseController(wrt)
+	_ = controller.SetWriteDeadline(time.Time{})
+	_ = controller.Flush()
+
+	err := stream(wrt, req)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		r.logger.Errorf("Error writing stream: %s", err.Error())
+	}
+}
+
+// writeFile serves a file result, handling Range and conditional requests.
+// Results built with FileOk carry the file path as their encoded payload.
+func (r *Router) writeFile(wrt http.ResponseWriter, req *http.Request, ctx *Context, res result.Result, encode []byte) {
+	open := res.FileOpener()
+	if open == nil {
+		r.writeHeaders(wrt, res, nil)
+		http.ServeFile(wrt, req, string(encode))
+		return
+	}
+
+	file, err := open()
+	if err != nil {
+		r.manageErr(wrt, req, ctx, r.fileErr(err))
+		return
+	}
+	defer file.Close()
+
+	r.writeHeaders(wrt, res, nil)
+	http.ServeContent(wrt, req, file.Name, file.Modified, file.Content)
+}
+
+func (r *Router) fileErr(err error) result.Result {
+	switch {
+	case errors.Is(err, fs.ErrNotExist), errors.Is(err, fs.ErrInvalid):
+		return result.ProblemErr(http.StatusNotFound)
+	case errors.Is(err, fs.ErrPermission):
+		return result.ProblemErr(http.StatusForbidden)
+	}
+
+	r.logger.Errorf("Error opening file: %s", err.Error())
+	return result.ProblemErr(http.StatusInternalServerError)
+}
+
+func (r *Router) writeErr(wrt http.ResponseWriter, result result.Result, encode []byte) {
+	headers := result.Encoder().Headers()
+	if headers["Content-Type"] == "text/plain" && result.Headers().Get("Content-Type") == "" {
+		r.writeHeaders(wrt, result, nil)
+		http.Error(wrt, string(encode), result.Status())
+		return
+	}
+
+	r.writeHeaders(wrt, result, headers)
+	wrt.Header().Set("X-Content-Type-Options", "nosniff")
+	wrt.WriteHeader(result.Status())
+
+	if _, err := wrt.Write(encode); err != nil {
+		r.logger.Errorf("Error writing response: %s", err.Error())
+	}
+}
+
+// writeHeaders copies the encoder headers, then the result headers and
+// cookies, into the response. It must run before WriteHeader.
+func (r *Router) writeHeaders(wrt http.ResponseWriter, result result.Result, encoder map[string]string) {
+	for k, v := range encoder {
+		wrt.Header().Set(k, v)
+	}
+
+	for k, v := range result.Headers() {
+		wrt.Header()[k] = v
+	}
+
+	for _, c := range result.Cookies() {
+		http.SetCookie(wrt, c)
+	}
+}
+
 func (r *Router) manageErr(wrt http.ResponseWriter, req *http.Request, context *Context, result result.Result) {
 	errorHandler, ok := r.errors.Get(req.Pattern)
 	if !ok {
@@ -561,7 +822,7 @@ func (r *Router) manageErr(wrt http.ResponseWriter, req *http.Request, context *
 		return
 	}
 
-	r.manageOk(wrt, req, result)
+	r.manageOk(wrt, req, context, result)
 }
 
 func (r *Router) managePanic(wrt http.ResponseWriter, req *http.Request, rec any) {
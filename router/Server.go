@@ -0,0 +1,85 @@
+package router
+
+import (
+	"crypto/tls"
+	stdlog "log"
+	"net/http"
+	"time"
+
+	"github.com/Rafael24595/go-web/router/configuration"
+)
+
+// ServerOptions defines the limits applied to the http.Server instances
+// created by the Router.
+//
+// Zero values keep the net/http defaults, which means no timeout at all.
+// Setting at least ReadHeaderTimeout is recommended to protect the server
+// against slowloris-style clients.
+//
+// Fields:
+//   - ReadTimeout: maximum duration for reading the entire request, including the body.
+//   - ReadHeaderTimeout: maximum duration for reading the request headers.
+//   - WriteTimeout: maximum duration before timing out writes of the response.
+//   - IdleTimeout: maximum time to wait for the next request on keep-alive connections.
+//   - MaxHeaderBytes: maximum number of bytes read while parsing the request headers.
+//   - TLSConfig: optional TLS configuration used by ListenTLS and ListenWithTLS.
+type ServerOptions struct {
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	TLSConfig         *tls.Config
+}
+
+// DefaultServerOptions returns the ServerOptions defined by the global
+// configuration (GO_WEB_READ_TIMEOUT, GO_WEB_READ_HEADER_TIMEOUT,
+// GO_WEB_WRITE_TIMEOUT, GO_WEB_IDLE_TIMEOUT and GO_WEB_MAX_HEADER_BYTES).
+func DefaultServerOptions() ServerOptions {
+	config := configuration.Instance()
+	return ServerOptions{
+		ReadTimeout:       config.ReadTimeout(),
+		ReadHeaderTimeout: config.ReadHeaderTimeout(),
+		WriteTimeout:      config.WriteTimeout(),
+		IdleTimeout:       config.IdleTimeout(),
+		MaxHeaderBytes:    config.MaxHeaderBytes(),
+	}
+}
+
+// Server sets the timeouts and limits applied to the servers started by
+// the Router.
+//
+// Example:
+//
+//	router.Server(router.ServerOptions{
+//	    ReadHeaderTimeout: 5 * time.Second,
+//	    WriteTimeout:      30 * time.Second,
+//	    IdleTimeout:       2 * time.Minute,
+//	    MaxHeaderBytes:    1 << 20,
+//	})
+//
+// Returns the Router itself for fluent configuration.
+func (r *Router) Server(options ServerOptions) *Router {
+	r.server = options
+	return r
+}
+
+func (r *Router) newServer(addr string, handler http.Handler) *http.Server {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadTimeout:       r.server.ReadTimeout,
+		ReadHeaderTimeout: r.server.ReadHeaderTimeout,
+		WriteTimeout:      r.server.WriteTimeout,
+		IdleTimeout:       r.server.IdleTimeout,
+		MaxHeaderBytes:    r.server.MaxHeaderBytes,
+		ErrorLog:          stdlog.New(r.logger, "", 0),
+		BaseContext:       r.lifecycle.baseContext,
+	}
+
+	if r.server.TLSConfig != nil {
+		server.TLSConfig = r.server.TLSConfig.Clone()
+	}
+
+	return server
+}
@@ -0,0 +1,91 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Rafael24595/go-web/router/result"
+	"github.com/Rafael24595/go-web/router/websocket"
+)
+
+// SocketHandler handles an upgraded WebSocket connection. The connection
+// is closed with a normal closure status when the handler returns, or
+// with a going away status when the Router shuts down.
+type SocketHandler = func(conn *websocket.Conn, req *http.Request, ctx *Context)
+
+// WebSocketHandler adapts a SocketHandler into a RequestHandler that
+// upgrades the connection with the given options.
+//
+// Since the upgrade happens inside the route handler, contextualizers,
+// group contextualizers and middlewares run before it, so the same
+// authentication applies to sockets and regular routes. Use it with
+// RouteWithOptions when a socket route needs custom HandlerOptions.
+//
+// Invalid handshakes are answered with a problem+json result: 426 Upgrade
+// Required for non-WebSocket requests, 403 Forbidden for rejected origins
+// and 400 Bad Request for malformed keys.
+func WebSocketHandler(handler SocketHandler, options websocket.Options) RequestHandler {
+	return func(wrt http.ResponseWriter, req *http.Request, ctx *Context) result.Result {
+		conn, err := websocket.Upgrade(wrt, req, options)
+		if err != nil {
+			return handshakeErr(err)
+		}
+		defer conn.Close(websocket.CloseNormal, "")
+
+		if closing := shutdownContext(req); closing != nil {
+			stop := context.AfterFunc(closing, func() {
+				conn.Close(websocket.CloseGoingAway, "server shutting down")
+			})
+			defer stop()
+		}
+
+		handler(conn, req, ctx)
+
+		return result.Continue()
+	}
+}
+
+// WebSocket registers a WebSocket route for GET requests on the given
+// pattern, using websocket.DefaultOptions.
+//
+// Example:
+//
+//	router.WebSocket(func(conn *websocket.Conn, r *http.Request, ctx *router.Context) {
+//	    for {
+//	        kind, message, err := conn.ReadMessage()
+//	        if err != nil {
+//	            return
+//	        }
+//	        conn.WriteMessage(kind, message)
+//	    }
+//	}, "/echo")
+//
+// Returns the Router itself for fluent configuration.
+func (r *Router) WebSocket(handler SocketHandler, pattern string, params ...any) *Router {
+	return r.Route(http.MethodGet, WebSocketHandler(handler, websocket.DefaultOptions()), pattern, params...)
+}
+
+// WebSocket registers a WebSocket route inside the group, using
+// websocket.DefaultOptions.
+//
+// Returns the Group itself for fluent configuration.
+func (g *Group) WebSocket(handler SocketHandler, pattern string, params ...any) *Group {
+	return g.Route(http.MethodGet, WebSocketHandler(handler, websocket.DefaultOptions()), pattern, params...)
+}
+
+func handshakeErr(err error) result.Result {
+	handshake := new(websocket.HandshakeError)
+	if !errors.As(err, &handshake) {
+		return result.ProblemErr(http.StatusInternalServerError, err)
+	}
+
+	res := result.ProblemErr(handshake.Status, err)
+	if handshake.Status == http.StatusUpgradeRequired {
+		res = res.
+			WithHeader("Upgrade", "websocket").
+			WithHeader("Sec-WebSocket-Version", websocket.VERSION)
+	}
+
+	return res
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Rafael24595/go-web/router/utils"
 )
@@ -16,8 +17,24 @@ var (
 
 // Configuration holds global application settings.
 type Configuration struct {
-	dev          bool
-	traceRequest bool
+	dev               bool
+	traceRequest      bool
+	readTimeout       time.Duration
+	readHeaderTimeout time.Duration
+	writeTimeout      time.Duration
+	idleTimeout       time.Duration
+	maxHeaderBytes    int
+	logLevel          string
+	logFormat         string
+	logSinks          []string
+	logEnable         []string
+	logDisable        []string
+	logMaxSize        int64
+	logDaily          bool
+	logCompress       bool
+	logMaxAge         time.Duration
+	logMaxCount       int
+	logReopen         bool
 }
 
 // Instance returns the singleton instance of Configuration.
@@ -26,15 +43,52 @@ type Configuration struct {
 //
 //   - GO_WEB_DEV: enables or disables development mode.
 //   - GO_WEB_TRACE_REQUEST: enables or disables HTTP request tracing.
+//   - GO_WEB_READ_TIMEOUT: maximum duration for reading the entire request.
+//   - GO_WEB_READ_HEADER_TIMEOUT: maximum duration for reading request headers.
+//   - GO_WEB_WRITE_TIMEOUT: maximum duration before timing out response writes.
+//   - GO_WEB_IDLE_TIMEOUT: maximum time to wait for the next keep-alive request.
+//   - GO_WEB_MAX_HEADER_BYTES: maximum size of the request headers.
+//   - GO_WEB_LOG_LEVEL: minimum level of the default logger (debug, info,
+//     warn or error).
+//   - GO_WEB_LOG_FORMAT: output of the default logger (plain, text or json).
+//   - GO_WEB_LOG_SINK: comma-separated outputs of the default logger
+//     (stdout, stderr or a file path).
+//   - GO_WEB_LOG_ENABLE: comma-separated categories logged at any level.
+//   - GO_WEB_LOG_DISABLE: comma-separated categories never logged.
+//   - GO_WEB_LOG_MAX_SIZE: size in bytes that rotates the log files.
+//   - GO_WEB_LOG_DAILY: rotates the log files every day.
+//   - GO_WEB_LOG_COMPRESS: gzips the rotated log files.
+//   - GO_WEB_LOG_MAX_AGE: maximum age of the rotated log files.
+//   - GO_WEB_LOG_MAX_COUNT: maximum number of rotated log files.
+//   - GO_WEB_LOG_REOPEN: reopens the log files on SIGHUP.
 //
-// If these environment variables are not present, default values (false) are used.
+// Durations use the time.ParseDuration format (e.g. "5s", "1m30s").
+// If these environment variables are not present, default values (false
+// or 0, meaning the net/http defaults, and an info level plain logger
+// writing to stderr) are used.
 func Instance() Configuration {
 	once.Do(func() {
 		kargs := readAllEnv(".env")
 
 		instance = &Configuration{
-			dev:          kargs["GO_WEB_DEV"].Boold(false),
-			traceRequest: kargs["GO_WEB_TRACE_REQUEST"].Boold(false),
+			dev:               kargs["GO_WEB_DEV"].Boold(false),
+			traceRequest:      kargs["GO_WEB_TRACE_REQUEST"].Boold(false),
+			readTimeout:       kargs["GO_WEB_READ_TIMEOUT"].Durationd(0),
+			readHeaderTimeout: kargs["GO_WEB_READ_HEADER_TIMEOUT"].Durationd(0),
+			writeTimeout:      kargs["GO_WEB_WRITE_TIMEOUT"].Durationd(0),
+			idleTimeout:       kargs["GO_WEB_IDLE_TIMEOUT"].Durationd(0),
+			maxHeaderBytes:    kargs["GO_WEB_MAX_HEADER_BYTES"].Intd(0),
+			logLevel:          kargs["GO_WEB_LOG_LEVEL"].Stringd("info"),
+			logFormat:         kargs["GO_WEB_LOG_FORMAT"].Stringd("plain"),
+			logSinks:          kargs["GO_WEB_LOG_SINK"].List(),
+			logEnable:         kargs["GO_WEB_LOG_ENABLE"].List(),
+			logDisable:        kargs["GO_WEB_LOG_DISABLE"].List(),
+			logMaxSize:        kargs["GO_WEB_LOG_MAX_SIZE"].Int64d(0),
+			logDaily:          kargs["GO_WEB_LOG_DAILY"].Boold(false),
+			logCompress:       kargs["GO_WEB_LOG_COMPRESS"].Boold(false),
+			logMaxAge:         kargs["GO_WEB_LOG_MAX_AGE"].Durationd(0),
+			logMaxCount:       kargs["GO_WEB_LOG_MAX_COUNT"].Intd(0),
+			logReopen:         kargs["GO_WEB_LOG_REOPEN"].Boold(false),
 		}
 	})
 
@@ -51,6 +105,86 @@ func (c Configuration) TraceRequest() bool {
 	return c.traceRequest
 }
 
+// ReadTimeout returns the maximum duration for reading the entire request.
+func (c Configuration) ReadTimeout() time.Duration {
+	return c.readTimeout
+}
+
+// ReadHeaderTimeout returns the maximum duration for reading request headers.
+func (c Configuration) ReadHeaderTimeout() time.Duration {
+	return c.readHeaderTimeout
+}
+
+// WriteTimeout returns the maximum duration before timing out response writes.
+func (c Configuration) WriteTimeout() time.Duration {
+	return c.writeTimeout
+}
+
+// IdleTimeout returns the maximum time to wait for the next keep-alive request.
+func (c Configuration) IdleTimeout() time.Duration {
+	return c.idleTimeout
+}
+
+// MaxHeaderBytes returns the maximum size of the request headers.
+func (c Configuration) MaxHeaderBytes() int {
+	return c.maxHeaderBytes
+}
+
+// LogLevel returns the minimum level of the default logger.
+func (c Configuration) LogLevel() string {
+	return c.logLevel
+}
+
+// LogFormat returns the output format of the default logger.
+func (c Configuration) LogFormat() string {
+	return c.logFormat
+}
+
+// LogSinks returns the outputs of the default logger.
+func (c Configuration) LogSinks() []string {
+	return c.logSinks
+}
+
+// LogEnable returns the categories logged regardless of the level.
+func (c Configuration) LogEnable() []string {
+	return c.logEnable
+}
+
+// LogDisable returns the categories that are never logged.
+func (c Configuration) LogDisable() []string {
+	return c.logDisable
+}
+
+// LogMaxSize returns the size in bytes that rotates the log files.
+func (c Configuration) LogMaxSize() int64 {
+	return c.logMaxSize
+}
+
+// LogDaily reports whether the log files are rotated every day.
+func (c Configuration) LogDaily() bool {
+	return c.logDaily
+}
+
+// LogCompress reports whether the rotated log files are gzipped.
+func (c Configuration) LogCompress() bool {
+	return c.logCompress
+}
+
+// LogMaxAge returns the maximum age of the rotated log files.
+func (c Configuration) LogMaxAge() time.Duration {
+	return c.logMaxAge
+}
+
+// LogMaxCount returns the maximum number of rotated log files.
+func (c Configuration) LogMaxCount() int {
+	return c.logMaxCount
+}
+
+// LogReopen reports whether the log files are reopened on SIGHUP.
+func (c Configuration) LogReopen() bool {
+	return c.logReopen
+}
+
 func readAllEnv(path string) map[string]utils.Argument {
 	envs := readDotEnv(path)
 	maps.Copy(envs, readEnv())
@@ -1,8 +1,13 @@
 package docs
 
 import (
+	"maps"
 	"net/http"
+	"reflect"
 	"strings"
+	"time"
+
+	"github.com/Rafael24595/go-web/router/result"
 )
 
 type handler = func(http.ResponseWriter, *http.Request)
@@ -16,13 +21,120 @@ type DocParameters map[string]string
 // DocOrderParameters maps parameter names to their order and description.
 type DocOrderParameters []DocParameter
 
+// DocParameter describes a path parameter. Type and Format follow the
+// OpenAPI data types (e.g. "integer" and "int64", or "string" and "uuid");
+// when Type is empty, the parameter is documented without schema.
 type DocParameter struct {
 	Code        string `json:"code"`
 	Description string `json:"description"`
+	Type        string `json:"type,omitempty"`
+	Format      string `json:"format,omitempty"`
 }
 
+// Parameter creates an untyped DocParameter.
 func Parameter(code string, description string) DocParameter {
-	return DocParameter{code, description}
+	return DocParameter{
+		Code:        code,
+		Description: description,
+	}
+}
+
+// ParameterInt creates a DocParameter documented as an integer.
+func ParameterInt(code string, description string) DocParameter {
+	return TypedParameter[int64](code, description)
+}
+
+// ParameterUUID creates a DocParameter documented as a UUID string.
+func ParameterUUID(code string, description string) DocParameter {
+	return DocParameter{
+		Code:        code,
+		Description: description,
+		Type:        "string",
+		Format:      "uuid",
+	}
+}
+
+// TypedParameter creates a DocParameter whose type is inferred from T,
+// matching the types supported by router.Path.
+func TypedParameter[T any](code string, description string) DocParameter {
+	kind, format := DataType(reflect.TypeFor[T]())
+	return DocParameter{
+		Code:        code,
+		Description: description,
+		Type:        kind,
+		Format:      format,
+	}
+}
+
+// DataType returns the OpenAPI type and format matching a Go scalar type.
+func DataType(t reflect.Type) (string, string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return "string", "date-time"
+	case reflect.TypeOf(time.Duration(0)):
+		return "string", ""
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean", ""
+	case reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return "integer", "int32"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return "integer", "int64"
+	case reflect.Float32:
+		return "number", "float"
+	case reflect.Float64:
+		return "number", "double"
+	default:
+		return "string", ""
+	}
+}
+
+// DocStructParameters builds the DocParameters of a struct type, as bound by
+// router.InputQuery or router.InputForm. Each exported field is documented
+// with the name defined by the given tag (or the field name) and the
+// description defined by the `description` tag.
+//
+// Example:
+//
+//	doc := docs.DocRoute{
+//	    Query: docs.DocStructParameters[Search]("query"),
+//	}
+func DocStructParameters[T any](tag string) DocParameters {
+	parameters := make(DocParameters)
+
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return parameters
+	}
+
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		parameters[name] = field.Tag.Get("description")
+	}
+
+	return parameters
 }
 
 // ParameterType defines the location type of a request parameter.
@@ -37,8 +149,12 @@ const (
 type MediaType string
 
 const (
-	JSON MediaType = "application/json"
-	XML  MediaType = "application/xml"
+	JSON      MediaType = "application/json"
+	XML       MediaType = "application/xml"
+	FORM      MediaType = "application/x-www-form-urlencoded"
+	MULTIPART MediaType = "multipart/form-data"
+	PROBLEM   MediaType = "application/problem+json"
+	BINARY    MediaType = "application/octet-stream"
 )
 
 // IDocViewer defines an interface for a documentation viewer.
@@ -102,12 +218,29 @@ type DocOperation struct {
 }
 
 // DocPayload represents a request or response body and its metadata.
+//
+// Accepts lists additional media types sharing the same payload, e.g. a
+// request body accepted both as JSON and XML, or a negotiated response.
+// Headers documents the response headers by name and description.
 type DocPayload struct {
 	Payload     any
 	MediaType   MediaType
+	Accepts     []MediaType
+	Headers     DocParameters
 	Description string
 }
 
+// WithHeader returns a copy of the DocPayload documenting an additional
+// response header.
+func (p DocPayload) WithHeader(name, description string) DocPayload {
+	headers := make(DocParameters, len(p.Headers)+1)
+	maps.Copy(headers, p.Headers)
+	headers[name] = description
+
+	p.Headers = headers
+	return p
+}
+
 // DocXmlPayload creates a DocPayload with XML media type.
 func DocXmlPayload[T any](description ...string) DocPayload {
 	var xml T
@@ -120,6 +253,48 @@ func DocJsonPayload[T any](description ...string) DocPayload {
 	return docPayload(json, JSON, description...)
 }
 
+// DocNegotiatePayload creates a DocPayload for a response built with
+// result.Negotiate, documenting every negotiable media type.
+func DocNegotiatePayload[T any](description ...string) DocPayload {
+	var item T
+	payload := docPayload(item, JSON, description...)
+
+	media := result.Encoders()
+	payload.MediaType = MediaType(media[0])
+	for _, v := range media[1:] {
+		payload.Accepts = append(payload.Accepts, MediaType(v))
+	}
+
+	return payload
+}
+
+// DocFormPayload creates a DocPayload with form-urlencoded media type.
+func DocFormPayload[T any](description ...string) DocPayload {
+	var form T
+	return docPayload(form, FORM, description...)
+}
+
+// DocProblem creates a DocPayload for an application/problem+json error
+// body. Every route documented with it shares the same Problem schema,
+// registered by the OpenAPI viewer as #/components/schemas/Problem.
+func DocProblem(description ...string) DocPayload {
+	return docPayload(result.Problem{}, PROBLEM, description...)
+}
+
+// DocFile creates a DocPayload for a binary response, such as a file
+// result, with the given media type (BINARY when empty).
+//
+// Example:
+//
+//	docs.DocFile("application/pdf", "The invoice").
+//	    WithHeader("Content-Disposition", "attachment; filename=invoice.pdf")
+func DocFile(media MediaType, description ...string) DocPayload {
+	if media == "" {
+		media = BINARY
+	}
+	return docPayload([]byte(nil), media, description...)
+}
+
 // DocText creates a DocPayload representing text or empty JSON body.
 func DocText(description ...string) DocPayload {
 	return docPayload("", JSON, description...)
@@ -4,9 +4,12 @@ import (
 	"encoding/xml"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/Rafael24595/go-web/router/docs"
+	"github.com/Rafael24595/go-web/router/result"
+	"github.com/Rafael24595/go-web/router/validator"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -46,13 +49,34 @@ func (f *FactoryStructToSchema) Components() *Components {
 // MakeSchema creates a schema reference for a given payload.
 // - If the payload is a struct, it will be added to the components.
 // - If the payload is a slice/array, it wraps the item schema in an "array" type.
+// - If the payload is a byte slice, it is described as a binary string.
 func (f *FactoryStructToSchema) MakeSchema(media docs.MediaType, root any) (*Schema, error) {
 	t := reflect.TypeOf(root)
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
 	}
 
-	return f.inferStruct(media, t)
+	if t == reflect.TypeFor[[]byte]() {
+		return &Schema{
+			Type:   "string",
+			Format: "binary",
+		}, nil
+	}
+
+	return f.inferStruct(f.schemaMedia(media), t)
+}
+
+// schemaMedia maps a media type to the serialization family used to read
+// field tags, so "text/xml" or "application/vnd.api+json" share the
+// XML and JSON schemas.
+func (f *FactoryStructToSchema) schemaMedia(media docs.MediaType) docs.MediaType {
+	switch {
+	case media == "text/xml", strings.HasSuffix(string(media), "+xml"):
+		return docs.XML
+	case strings.HasSuffix(string(media), "+json"):
+		return docs.JSON
+	}
+	return media
 }
 
 func (f *FactoryStructToSchema) collectSchema(media docs.MediaType, t reflect.Type) (string, bool, error) {
@@ -124,7 +148,7 @@ func (f *FactoryStructToSchema) makeSchema(media docs.MediaType, t reflect.Type)
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
 
-		if field.Anonymous || f.isMiscField(field) {
+		if field.Anonymous || f.isMiscField(field) || f.isIgnoredField(media, field) {
 			continue
 		}
 
@@ -136,6 +160,7 @@ func (f *FactoryStructToSchema) makeSchema(media docs.MediaType, t reflect.Type)
 		}
 
 		ref.Description = field.Tag.Get("description")
+		isRequired = f.applyRules(field, ref, isRequired)
 
 		switch media {
 		case docs.XML:
@@ -150,6 +175,11 @@ func (f *FactoryStructToSchema) makeSchema(media docs.MediaType, t reflect.Type)
 				isRequired = isRequired && !jsonOmitempty
 				ref = jsonRef
 			}
+		case docs.FORM:
+			if formTag, formRef := f.isFormField(field, ref); formRef != nil {
+				name = formTag
+				ref = formRef
+			}
 		}
 
 		schema = f.addProperty(schema, name, ref, isRequired)
@@ -162,6 +192,18 @@ func (f *FactoryStructToSchema) isMiscField(field reflect.StructField) bool {
 	return field.Type == reflect.TypeOf(xml.Name{})
 }
 
+func (f *FactoryStructToSchema) isIgnoredField(media docs.MediaType, field reflect.StructField) bool {
+	switch media {
+	case docs.XML:
+		return field.Tag.Get("xml") == "-"
+	case docs.JSON:
+		return field.Tag.Get("json") == "-"
+	case docs.FORM:
+		return field.Tag.Get("form") == "-"
+	}
+	return false
+}
+
 func (f *FactoryStructToSchema) addProperty(schema *Schema, name string, property *Schema, isRequired bool) *Schema {
 	schema.Properties[name] = property
 	if isRequired {
@@ -183,6 +225,15 @@ func (f *FactoryStructToSchema) isJsonField(field reflect.StructField, ref *Sche
 	return tag, omitEmpty, ref
 }
 
+func (f *FactoryStructToSchema) isFormField(field reflect.StructField, ref *Schema) (string, *Schema) {
+	tag := strings.Split(field.Tag.Get("form"), ",")[0]
+	if tag == "" || tag == "-" {
+		return "", nil
+	}
+
+	return tag, ref
+}
+
 func (f *FactoryStructToSchema) isXmlField(field reflect.StructField, ref *Schema) (string, bool, *Schema) {
 	attribute := field.Tag.Get("xml")
 
@@ -237,6 +288,84 @@ func (f *FactoryStructToSchema) defineXmlName(name string, ref *Schema, attribut
 	return ref
 }
 
+// applyRules translates the `validate` tag of a field into schema
+// constraints and returns whether the field is required.
+func (f *FactoryStructToSchema) applyRules(field reflect.StructField, ref *Schema, isRequired bool) bool {
+	for _, rule := range validator.Rules(field.Tag.Get(validator.TAG)) {
+		switch rule.Name {
+		case validator.REQUIRED:
+			isRequired = true
+		case validator.OMITEMPTY:
+			isRequired = false
+		case validator.MIN, validator.MAX, validator.LEN:
+			f.applySize(ref, rule)
+		case validator.EMAIL:
+			ref.Format = "email"
+		case validator.PATTERN:
+			ref.Pattern = rule.Param
+		case validator.ONEOF:
+			ref.Enum = f.makeEnum(ref.Type, strings.Fields(rule.Param))
+		}
+	}
+
+	return isRequired
+}
+
+func (f *FactoryStructToSchema) applySize(ref *Schema, rule validator.Rule) {
+	limit, err := strconv.ParseFloat(rule.Param, 64)
+	if err != nil {
+		return
+	}
+
+	isMin := rule.Name == validator.MIN || rule.Name == validator.LEN
+	isMax := rule.Name == validator.MAX || rule.Name == validator.LEN
+	size := int(limit)
+
+	switch ref.Type {
+	case "string":
+		if isMin {
+			ref.MinLength = &size
+		}
+		if isMax {
+			ref.MaxLength = &size
+		}
+	case "array":
+		if isMin {
+			ref.MinItems = &size
+		}
+		if isMax {
+			ref.MaxItems = &size
+		}
+	case "integer", "number":
+		if isMin {
+			ref.Minimum = &limit
+		}
+		if isMax {
+			ref.Maximum = &limit
+		}
+	}
+}
+
+func (f *FactoryStructToSchema) makeEnum(kind string, options []string) []interface{} {
+	enum := make([]interface{}, 0, len(options))
+	for _, o := range options {
+		switch kind {
+		case "integer":
+			if v, err := strconv.ParseInt(o, 10, 64); err == nil {
+				enum = append(enum, v)
+				continue
+			}
+		case "number":
+			if v, err := strconv.ParseFloat(o, 64); err == nil {
+				enum = append(enum, v)
+				continue
+			}
+		}
+		enum = append(enum, o)
+	}
+	return enum
+}
+
 func (f *FactoryStructToSchema) canBeRequired(field reflect.StructField) bool {
 	return field.Type.Kind() != reflect.Ptr &&
 		field.Type.Kind() != reflect.Slice &&
@@ -325,6 +454,10 @@ func (f *FactoryStructToSchema) makeStructName(media docs.MediaType, t reflect.T
 		name = "Anon"
 	}
 
+	if media == docs.JSON && t == reflect.TypeFor[result.Problem]() {
+		return name, PROBLEM_SCHEMA
+	}
+
 	mediaName := f.makeMediaName(media, t.PkgPath(), name)
 
 	if xmlName, ok := f.hasXmlRoot(t); ok {
@@ -354,6 +487,8 @@ func (f *FactoryStructToSchema) makeMediaName(media docs.MediaType, pkg, name st
 		media = "xml"
 	case docs.JSON:
 		media = "json"
+	case docs.FORM:
+		media = "form"
 	default:
 		media = ""
 	}
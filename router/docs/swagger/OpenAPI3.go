@@ -152,6 +152,13 @@ type Schema struct {
 	Items                *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
 	Required             []string           `json:"required,omitempty" yaml:"required,omitempty"`
 	Enum                 []interface{}      `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
 	Description          string             `json:"description,omitempty" yaml:"description,omitempty"`
 	AdditionalProperties *Schema            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
 	AllOf                AllOf              `json:"allOf,omitempty" yaml:"allOf,omitempty"`
@@ -6,11 +6,13 @@ import (
 	"maps"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/Rafael24595/go-web/router/docs"
 	"github.com/Rafael24595/go-web/router/log"
+	"github.com/Rafael24595/go-web/router/result"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 	"gopkg.in/yaml.v3"
 )
@@ -20,6 +22,10 @@ const SWAGGER string = "SWAGGER"
 const SWAGGER_ROUTE = "/swagger/"
 const SWAGGER_JSON = "/swagger/doc.json"
 
+// PROBLEM_SCHEMA is the name of the shared component describing RFC 9457
+// problem details, referenced as #/components/schemas/Problem.
+const PROBLEM_SCHEMA = "Problem"
+
 // OpenAPI3ViewerOptions defines the configuration for the OpenAPI 3.0 viewer.
 type OpenAPI3ViewerOptions struct {
 	Version   string // API version
@@ -37,15 +43,17 @@ type OpenAPI3Viewer struct {
 	logger     log.Log
 	data       OpenAPI3
 	factory    *FactoryStructToSchema
+	problem    *Schema
 	headers    map[string]map[string]string
 	cookies    map[string]map[string]string
 	responses  map[string]map[string]Response
 	stringData string
 }
 
-// NewViewer creates a new OpenAPI3Viewer with default values.
+// NewViewer creates a new OpenAPI3Viewer with default values. The Problem
+// schema is always registered in the components.
 func NewViewer() *OpenAPI3Viewer {
-	return &OpenAPI3Viewer{
+	viewer := &OpenAPI3Viewer{
 		data:       OpenAPI3{},
 		logger:     log.DefaultLogger(),
 		factory:    NewFactoryStructToSchema(),
@@ -54,6 +62,14 @@ func NewViewer() *OpenAPI3Viewer {
 		responses:  make(map[string]map[string]Response),
 		stringData: "",
 	}
+
+	problem, err := viewer.factory.MakeSchema(docs.PROBLEM, result.Problem{})
+	if err != nil {
+		viewer.logger.Error(err)
+	}
+	viewer.problem = problem
+
+	return viewer
 }
 
 // Logger sets the logger for the viewer and returns itself.
@@ -262,7 +278,7 @@ func (v *OpenAPI3Viewer) makeParameters(path string, route docs.DocOperation) []
 
 	if route.Parameters != nil {
 		for _, d := range route.Parameters {
-			parameters = append(parameters, v.makeParameter(d.Code, d.Description, "path"))
+			parameters = append(parameters, v.makePathParameter(d))
 		}
 	}
 
@@ -281,6 +297,17 @@ func (v *OpenAPI3Viewer) makeParameters(path string, route docs.DocOperation) []
 	return parameters
 }
 
+func (v *OpenAPI3Viewer) makePathParameter(parameter docs.DocParameter) Parameter {
+	path := v.makeParameter(parameter.Code, parameter.Description, "path")
+	if parameter.Type != "" {
+		path.Schema = &Schema{
+			Type:   parameter.Type,
+			Format: parameter.Format,
+		}
+	}
+	return path
+}
+
 func (v *OpenAPI3Viewer) makeCookie(name string, description string) Parameter {
 	cookie := v.makeParameter(name, description, "cookie")
 	cookie.Schema = &Schema{
@@ -305,6 +332,8 @@ func (v *OpenAPI3Viewer) makeRequest(route docs.DocOperation) *RequestBody {
 		content[string(contentType)] = *media
 	}
 
+	maps.Copy(content, v.makeAcceptedContent(route.Request))
+
 	if contentType, media := v.makeFileRequest(route); media != nil {
 		content[contentType] = *media
 	}
@@ -331,6 +360,27 @@ func (v *OpenAPI3Viewer) makeMainRequest(route docs.DocOperation) (docs.MediaTyp
 	}
 }
 
+func (v *OpenAPI3Viewer) makeAcceptedContent(payload docs.DocPayload) map[string]MediaType {
+	content := make(map[string]MediaType)
+	if payload.Payload == nil {
+		return content
+	}
+
+	for _, media := range payload.Accepts {
+		schema, err := v.factory.MakeSchema(media, payload.Payload)
+		if err != nil {
+			v.logger.Error(err)
+			continue
+		}
+
+		content[string(media)] = MediaType{
+			Schema: schema,
+		}
+	}
+
+	return content
+}
+
 func (v *OpenAPI3Viewer) makeFileRequest(route docs.DocOperation) (string, *MediaType) {
 	if len(route.Files) == 0 {
 		return "", nil
@@ -367,9 +417,60 @@ func (v *OpenAPI3Viewer) makeResponses(path string, route docs.DocOperation) map
 
 	maps.Copy(result, reponses)
 
+	for _, status := range generatedProblems(route) {
+		if _, ok := result[string(status)]; !ok {
+			result[string(status)] = v.makeProblemResponse(status)
+		}
+	}
+
 	return result
 }
 
+// generatedProblems returns the problem responses the router may answer
+// on its own for the route: 400, 415 and 422 when decoding a request body
+// or query, 406 for negotiated responses and 412 for responses carrying
+// validators.
+func generatedProblems(route docs.DocOperation) []docs.StatusCode {
+	statuses := make([]docs.StatusCode, 0)
+
+	if route.Request.Payload != nil || len(route.Files) > 0 {
+		statuses = append(statuses, docs.StatusBadRequest, docs.StatusUnsupportedMediaType, docs.StatusUnprocessableEntity)
+	} else if len(route.Query) > 0 {
+		statuses = append(statuses, docs.StatusBadRequest)
+	}
+
+	negotiated, validated := false, false
+	for _, response := range route.Responses {
+		negotiated = negotiated || len(response.Accepts) > 0
+		for name := range response.Headers {
+			if strings.EqualFold(name, "ETag") || strings.EqualFold(name, "Last-Modified") {
+				validated = true
+			}
+		}
+	}
+
+	if negotiated {
+		statuses = append(statuses, docs.StatusNotAcceptable)
+	}
+	if validated {
+		statuses = append(statuses, docs.StatusPreconditionFailed)
+	}
+
+	return statuses
+}
+
+func (v *OpenAPI3Viewer) makeProblemResponse(status docs.StatusCode) Response {
+	code, _ := strconv.Atoi(string(status))
+	return Response{
+		Description: http.StatusText(code),
+		Content: map[string]MediaType{
+			string(docs.PROBLEM): {
+				Schema: v.problem,
+			},
+		},
+	}
+}
+
 func (v *OpenAPI3Viewer) makeResponsesFromMap(responses map[docs.StatusCode]docs.DocPayload) map[string]Response {
 	if len(responses) == 0 {
 		return make(map[string]Response)
@@ -382,12 +483,35 @@ func (v *OpenAPI3Viewer) makeResponsesFromMap(responses map[docs.StatusCode]docs
 			v.logger.Error(err)
 			return nil
 		}
+		content := map[string]MediaType{
+			string(response.MediaType): {
+				Schema: main,
+			},
+		}
+
+		maps.Copy(content, v.makeAcceptedContent(response))
+
 		result[string(status)] = Response{
 			Description: response.Description,
-			Content: map[string]MediaType{
-				string(response.MediaType): {
-					Schema: main,
-				},
+			Headers:     v.makeResponseHeaders(response.Headers),
+			Content:     content,
+		}
+	}
+
+	return result
+}
+
+func (v *OpenAPI3Viewer) makeResponseHeaders(headers docs.DocParameters) map[string]Header {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	result := make(map[string]Header)
+	for name, description := range headers {
+		result[name] = Header{
+			Description: description,
+			Schema: &Schema{
+				Type: "string",
 			},
 		}
 	}
@@ -0,0 +1,260 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Filter is a Log that drops records under a minimum level or of disabled
+// categories, and routes categories to dedicated loggers. It must be fully
+// configured before it is used.
+//
+// Example:
+//
+//	logger := log.NewFilter(log.DefaultLogger()).
+//	    Level(slog.LevelWarn).
+//	    Enable("SWAGGER").
+//	    Disable("DEV-REQUEST").
+//	    Route(accessLogger, "ACCESS")
+type Filter struct {
+	logger  Log
+	level   slog.Level
+	levels  map[string]slog.Level
+	enabled map[string]bool
+	routes  map[string]Log
+}
+
+// NewFilter creates a Filter over the given logger, letting every record
+// at info level or above through.
+func NewFilter(logger Log) *Filter {
+	return &Filter{
+		logger:  logger,
+		level:   slog.LevelInfo,
+		levels:  make(map[string]slog.Level),
+		enabled: make(map[string]bool),
+		routes:  make(map[string]Log),
+	}
+}
+
+// ParseLevel parses a level name (debug, info, warn or error, optionally
+// with an offset like "info+2"). The category names message, warning and
+// error are accepted too.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case MESSAGE:
+		return slog.LevelInfo, nil
+	case WARNING:
+		return slog.LevelWarn, nil
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+		return slog.LevelInfo, fmt.Errorf("invalid log level '%s'", name)
+	}
+	return level, nil
+}
+
+// Level sets the minimum level of the records to log.
+//
+// Returns the Filter instance for fluent configuration.
+func (f *Filter) Level(level slog.Level) *Filter {
+	f.level = level
+	return f
+}
+
+// CategoryLevel sets the level of a custom category, info by default.
+//
+// Returns the Filter instance for fluent configuration.
+func (f *Filter) CategoryLevel(category string, level slog.Level) *Filter {
+	f.levels[strings.ToUpper(category)] = level
+	return f
+}
+
+// Enable logs the given categories regardless of the minimum level.
+//
+// Returns the Filter instance for fluent configuration.
+func (f *Filter) Enable(categories ...string) *Filter {
+	for _, v := range categories {
+		f.enabled[strings.ToUpper(v)] = true
+	}
+	return f
+}
+
+// Disable never logs the given categories.
+//
+// Returns the Filter instance for fluent configuration.
+func (f *Filter) Disable(categories ...string) *Filter {
+	for _, v := range categories {
+		f.enabled[strings.ToUpper(v)] = false
+	}
+	return f
+}
+
+// Route sends the records of the given categories to another logger.
+//
+// Returns the Filter instance for fluent configuration.
+func (f *Filter) Route(logger Log, categories ...string) *Filter {
+	for _, v := range categories {
+		f.routes[strings.ToUpper(v)] = logger
+	}
+	return f
+}
+
+// Enabled reports whether records of the category are logged.
+func (f *Filter) Enabled(category string) bool {
+	category = strings.ToUpper(category)
+	if enabled, ok := f.enabled[category]; ok {
+		return enabled
+	}
+
+	level, ok := f.levels[category]
+	if !ok {
+		level = CategoryLevel(category)
+	}
+	return level >= f.level
+}
+
+func (f *Filter) target(category string) (Log, bool) {
+	if !f.Enabled(category) {
+		return nil, false
+	}
+	if logger, ok := f.routes[strings.ToUpper(category)]; ok {
+		return logger, true
+	}
+	return f.logger, true
+}
+
+func (f *Filter) Attrs(category string, message string, attrs ...slog.Attr) {
+	if logger, ok := f.target(category); ok {
+		Attrs(logger, category, message, attrs...)
+	}
+}
+
+func (f *Filter) With(attrs ...slog.Attr) StructuredLog {
+	with := func(logger Log) Log {
+		if structured, ok := logger.(StructuredLog); ok {
+			return structured.With(attrs...)
+		}
+		return logger
+	}
+
+	filter := NewFilter(with(f.logger)).Level(f.level)
+	for k, v := range f.levels {
+		filter.levels[k] = v
+	}
+	for k, v := range f.enabled {
+		filter.enabled[k] = v
+	}
+	for k, v := range f.routes {
+		filter.routes[k] = with(v)
+	}
+	return filter
+}
+
+func (f *Filter) Custom(category string, message string) {
+	if logger, ok := f.target(category); ok {
+		logger.Custom(category, message)
+	}
+}
+
+func (f *Filter) Custome(category string, err error) {
+	if logger, ok := f.target(category); ok {
+		logger.Custome(category, err)
+	}
+}
+
+func (f *Filter) Customf(category string, format string, args ...any) {
+	if logger, ok := f.target(category); ok {
+		logger.Customf(category, format, args...)
+	}
+}
+
+func (f *Filter) Message(message string) {
+	if logger, ok := f.target(MESSAGE); ok {
+		logger.Message(message)
+	}
+}
+
+func (f *Filter) Messagef(format string, args ...any) {
+	if logger, ok := f.target(MESSAGE); ok {
+		logger.Messagef(format, args...)
+	}
+}
+
+func (f *Filter) Warning(message string) {
+	if logger, ok := f.target(WARNING); ok {
+		logger.Warning(message)
+	}
+}
+
+func (f *Filter) Warningf(format string, args ...any) {
+	if logger, ok := f.target(WARNING); ok {
+		logger.Warningf(format, args...)
+	}
+}
+
+func (f *Filter) Error(err error) {
+	if logger, ok := f.target(ERROR); ok {
+		logger.Error(err)
+	}
+}
+
+func (f *Filter) Errors(message string) {
+	if logger, ok := f.target(ERROR); ok {
+		logger.Errors(message)
+	}
+}
+
+func (f *Filter) Errorf(format string, args ...any) {
+	if logger, ok := f.target(ERROR); ok {
+		logger.Errorf(format, args...)
+	}
+}
+
+func (f *Filter) Write(slice []byte) (n int, err error) {
+	if logger, ok := f.target(WARNING); ok {
+		return logger.Write(slice)
+	}
+	return len(slice), nil
+}
+
+// Flush flushes the filtered logger and every routed logger.
+func (f *Filter) Flush() error {
+	errs := make([]error, 0)
+	for _, logger := range f.loggers() {
+		if flusher, ok := logger.(Flusher); ok {
+			errs = append(errs, flusher.Flush())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes the filtered logger and every routed logger.
+func (f *Filter) Close() error {
+	errs := make([]error, 0)
+	for _, logger := range f.loggers() {
+		if flusher, ok := logger.(Flusher); ok {
+			errs = append(errs, flusher.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (f *Filter) loggers() []Log {
+	loggers := []Log{f.logger}
+	for _, logger := range f.routes {
+		duplicated := false
+		for _, v := range loggers {
+			if v == logger {
+				duplicated = true
+				break
+			}
+		}
+		if !duplicated {
+			loggers = append(loggers, logger)
+		}
+	}
+	return loggers
+}
@@ -3,12 +3,16 @@ package log
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const (
+	DEBUG   string = "DEBUG"
 	MESSAGE string = "MESSAGE"
 	WARNING string = "WARNING"
 	ERROR   string = "ERROR"
@@ -28,12 +32,93 @@ type Log interface {
 	Write([]byte) (int, error)
 }
 
+// Flusher is implemented by loggers that buffer records. The Router
+// closes its logger when it shuts down, so no record is lost on exit.
+type Flusher interface {
+	// Flush blocks until every record logged before the call is written.
+	Flush() error
+	// Close flushes the pending records and releases the logger. Records
+	// logged afterwards are written synchronously.
+	Close() error
+}
+
+// Policy defines what a buffered logger does when its queue is full.
+type Policy int
+
+const (
+	// BLOCK makes the caller wait until the queue has room.
+	BLOCK Policy = iota
+	// DROP discards the record and reports the number of dropped records
+	// once the queue drains.
+	DROP
+)
+
+const DEFAULT_QUEUE_SIZE = 1024
+
+// AsyncOpts configures the logger built by NewAsyncLogger.
+//
+// Writer defaults to os.Stderr and QueueSize to DEFAULT_QUEUE_SIZE.
+type AsyncOpts struct {
+	Writer    io.Writer
+	QueueSize int
+	Policy    Policy
+}
+
+type entry struct {
+	timestamp int64
+	category  string
+	message   string
+	flushed   chan struct{}
+}
+
 type defaultLogger struct {
-	mu sync.Mutex
+	writer  io.Writer
+	policy  Policy
+	queue   chan entry
+	done    chan struct{}
+	start   sync.Once
+	mu      sync.RWMutex
+	write   sync.Mutex
+	closed  bool
+	started atomic.Bool
+	dropped atomic.Int64
 }
 
+// DefaultLogger creates the logger used by default by the Router and the
+// viewers, configured by the GO_WEB_LOG_* keys. Without them, it writes
+// "(GO-WEB)" prefixed lines of info level or above to the standard error.
+//
+// An invalid configuration falls back to that logger, which then reports
+// the error.
 func DefaultLogger() Log {
-	return &defaultLogger{}
+	logger, err := NewLogger(ConfiguredOpts())
+	if err != nil {
+		logger = NewAsyncLogger(AsyncOpts{})
+		logger.Warningf("Invalid logger configuration: %s", err.Error())
+	}
+	return logger
+}
+
+// NewAsyncLogger creates a Log whose records are written in order by a
+// single goroutine, through a bounded queue. The caller never waits for
+// the writer unless the queue is full and the policy is BLOCK.
+func NewAsyncLogger(opts AsyncOpts) Log {
+	writer := opts.Writer
+	if writer == nil {
+		writer = os.Stderr
+	}
+
+	size := opts.QueueSize
+	if size <= 0 {
+		size = DEFAULT_QUEUE_SIZE
+	}
+
+	return &defaultLogger{
+		writer: writer,
+		policy: opts.Policy,
+		queue:  make(chan entry, size),
+		done:   make(chan struct{}),
+	}
 }
 
 func (l *defaultLogger) Custom(category string, message string) {
@@ -86,24 +171,110 @@ func (l *defaultLogger) Write(slice []byte) (n int, err error) {
 	return len(slice), nil
 }
 
-func (l *defaultLogger) record(category string, message string) {
-	timestamp := time.Now().UnixMilli()
-	go l.print(timestamp, category, message)
+func (l *defaultLogger) Flush() error {
+	l.mu.RLock()
+	if l.closed || !l.started.Load() {
+		l.mu.RUnlock()
+		return nil
+	}
+
+	flushed := make(chan struct{})
+	l.queue <- entry{flushed: flushed}
+	l.mu.RUnlock()
+
+	<-flushed
+	return nil
 }
 
-func (l *defaultLogger) print(timestamp int64, category string, message string) {
+func (l *defaultLogger) Close() error {
 	l.mu.Lock()
-	defer l.mu.Unlock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil
+	}
+
+	l.closed = true
+	close(l.queue)
+	l.mu.Unlock()
+
+	if l.started.Load() {
+		<-l.done
+	}
+	return nil
+}
+
+func (l *defaultLogger) record(category string, message string) {
+	entry := entry{
+		timestamp: time.Now().UnixMilli(),
+		category:  category,
+		message:   message,
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if l.closed {
+		l.print(entry)
+		return
+	}
+
+	l.start.Do(func() {
+		l.started.Store(true)
+		go l.run()
+	})
+
+	if l.policy == DROP {
+		select {
+		case l.queue <- entry:
+		default:
+			l.dropped.Add(1)
+		}
+		return
+	}
+
+	l.queue <- entry
+}
+
+func (l *defaultLogger) run() {
+	defer close(l.done)
+
+	for entry := range l.queue {
+		if entry.flushed != nil {
+			close(entry.flushed)
+			continue
+		}
+
+		l.print(entry)
+
+		if dropped := l.dropped.Swap(0); dropped > 0 {
+			l.print(l.droppedEntry(dropped))
+		}
+	}
+
+	if dropped := l.dropped.Swap(0); dropped > 0 {
+		l.print(l.droppedEntry(dropped))
+	}
+}
+
+func (l *defaultLogger) droppedEntry(dropped int64) entry {
+	return entry{
+		timestamp: time.Now().UnixMilli(),
+		category:  WARNING,
+		message:   fmt.Sprintf("%d log records dropped, the queue is full", dropped),
+	}
+}
+
+func (l *defaultLogger) print(entry entry) {
+	l.write.Lock()
+	defer l.write.Unlock()
 
-	formatted := fmt.Sprintf("(GO-WEB) - %s - [%s]: %s", FormatMilliseconds(timestamp), category, message)
-	println(formatted)
+	fmt.Fprintf(l.writer, "(GO-WEB) - %s - [%s]: %s\n", FormatMilliseconds(entry.timestamp), entry.category, entry.message)
 }
 
 func FormatMilliseconds(timestamp int64) string {
 	if timestamp == 0 {
 		return "N/A"
 	}
-	seconds := timestamp / 1000
-	time := time.Unix(seconds, 0)
-	return time.Format("2006-01-02 15:04:05")
+	time := time.UnixMilli(timestamp)
+	return time.Format("2006-01-02 15:04:05.000")
 }
@@ -0,0 +1,433 @@
+package log
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const rotationStamp = "2006-01-02T15-04-05.000"
+
+// RotationOpts configures the rotation and retention of a RotatingFile.
+// Zero values disable the corresponding feature.
+//
+//   - MaxSize: rotates before a write would exceed this size in bytes.
+//   - Daily: rotates on the first write of each day.
+//   - Compress: gzips the rotated files.
+//   - MaxAge: removes rotated files older than this.
+//   - MaxCount: keeps at most this number of rotated files.
+//   - Reopen: reopens the file at its path on SIGHUP, see ReopenOn. Only
+//     applied by RotatingFileSink.
+type RotationOpts struct {
+	MaxSize  int64
+	Daily    bool
+	Compress bool
+	MaxAge   time.Duration
+	MaxCount int
+	Reopen   bool
+}
+
+// RotatingFile is a log file that is renamed with a timestamp suffix and
+// replaced by a new one when it grows too large or a day ends, e.g.
+// "app.log" becomes "app-2024-03-01T23-59-59.999.log". The suffix is the
+// time of the last write to the rotated file, so a daily file carries the
+// day it covers. It is safe for concurrent use.
+type RotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	opts     RotationOpts
+	file     *os.File
+	closed   bool
+	size     int64
+	modified time.Time
+	maintain sync.Mutex
+	pending  sync.WaitGroup
+	signals  chan os.Signal
+	stop     chan struct{}
+}
+
+// NewRotatingFile opens the log file in append mode, creating it and its
+// directories when missing.
+func NewRotatingFile(path string, opts RotationOpts) (*RotatingFile, error) {
+	file := &RotatingFile{
+		path: path,
+		opts: opts,
+	}
+
+	if err := file.open(); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// ReopenOn reopens the file at its path whenever the process receives
+// one of the given signals, so that external tools like logrotate can
+// move it away. Without signals, SIGHUP is used.
+//
+// Returns the RotatingFile instance for fluent configuration.
+func (f *RotatingFile) ReopenOn(signals ...os.Signal) *RotatingFile {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.signals != nil {
+		signal.Stop(f.signals)
+		close(f.stop)
+	}
+
+	f.signals = make(chan os.Signal, 1)
+	f.stop = make(chan struct{})
+	signal.Notify(f.signals, signals...)
+
+	go f.watch(f.signals, f.stop)
+
+	return f
+}
+
+func (f *RotatingFile) watch(signals chan os.Signal, stop chan struct{}) {
+	for {
+		select {
+		case <-signals:
+			if err := f.Reopen(); err != nil {
+				fmt.Fprintf(os.Stderr, "(GO-WEB) - [%s]: cannot reopen log file: %s\n", ERROR, err.Error())
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Write appends to the file, rotating it first if needed. If a previous
+// rotation or reopen could not open the file, it is opened again.
+func (f *RotatingFile) Write(data []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if f.expired(int64(len(data))) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(data)
+	f.size += int64(n)
+	f.modified = time.Now()
+	return n, err
+}
+
+// Rotate rotates the file immediately.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+	if f.file == nil {
+		return f.open()
+	}
+	return f.rotate()
+}
+
+// Reopen closes the file and opens the one found at its path, creating it
+// if it was moved or removed.
+func (f *RotatingFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+
+	var err error
+	if f.file != nil {
+		err = f.file.Close()
+		f.file = nil
+	}
+	return errors.Join(err, f.open())
+}
+
+// Close stops the signal watcher, waits for pending compressions and
+// closes the file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	if f.signals != nil {
+		signal.Stop(f.signals)
+		close(f.stop)
+		f.signals = nil
+	}
+
+	var err error
+	if f.file != nil {
+		err = f.file.Close()
+		f.file = nil
+	}
+	f.closed = true
+	f.mu.Unlock()
+
+	f.pending.Wait()
+	return err
+}
+
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.modified = info.ModTime()
+	return nil
+}
+
+func (f *RotatingFile) expired(size int64) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.opts.MaxSize > 0 && f.size+size > f.opts.MaxSize {
+		return true
+	}
+	return f.opts.Daily && time.Now().Format(time.DateOnly) != f.modified.Format(time.DateOnly)
+}
+
+// rotate renames the current file and opens a new one. The handle is
+// released first, so a failed open leaves the file to be opened again by
+// the next write.
+func (f *RotatingFile) rotate() error {
+	err := f.file.Close()
+	f.file = nil
+	if err != nil {
+		return errors.Join(err, f.open())
+	}
+
+	rotated := f.rotatedName(f.modified)
+	if err := os.Rename(f.path, rotated); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Join(err, f.open())
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	f.pending.Add(1)
+	go func() {
+		defer f.pending.Done()
+		f.maintenance(rotated)
+	}()
+
+	return nil
+}
+
+func (f *RotatingFile) rotatedName(modified time.Time) string {
+	ext := filepath.Ext(f.path)
+	base := strings.TrimSuffix(f.path, ext)
+	stamp := modified.Local().Format(rotationStamp)
+
+	name := fmt.Sprintf("%s-%s%s", base, stamp, ext)
+	for i := 1; exists(name) || exists(name+".gz"); i++ {
+		name = fmt.Sprintf("%s-%s.%d%s", base, stamp, i, ext)
+	}
+	return name
+}
+
+// maintenance compresses the rotated file and applies the retention
+// rules. It runs in the background, one at a time.
+func (f *RotatingFile) maintenance(rotated string) {
+	f.maintain.Lock()
+	defer f.maintain.Unlock()
+
+	if f.opts.Compress {
+		if err := compressFile(rotated); err != nil {
+			fmt.Fprintf(os.Stderr, "(GO-WEB) - [%s]: cannot compress log file: %s\n", ERROR, err.Error())
+		}
+	}
+
+	if err := f.retain(); err != nil {
+		fmt.Fprintf(os.Stderr, "(GO-WEB) - [%s]: cannot remove log files: %s\n", ERROR, err.Error())
+	}
+}
+
+// Rotated returns the paths of the rotated files, oldest first.
+func (f *RotatingFile) Rotated() ([]string, error) {
+	files, err := f.rotated()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, len(files))
+	for i, v := range files {
+		paths[i] = v.path
+	}
+	return paths, nil
+}
+
+type rotatedFile struct {
+	path     string
+	rotated  time.Time
+	sequence int
+}
+
+func (f *RotatingFile) rotated() ([]rotatedFile, error) {
+	dir := filepath.Dir(f.path)
+	ext := filepath.Ext(f.path)
+	prefix := filepath.Base(strings.TrimSuffix(f.path, ext)) + "-"
+	stampLen := len(rotationStamp)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]rotatedFile, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		stamp := strings.TrimPrefix(name, prefix)
+		if len(stamp) < stampLen {
+			continue
+		}
+
+		rotated, err := time.ParseInLocation(rotationStamp, stamp[:stampLen], time.Local)
+		if err != nil {
+			continue
+		}
+
+		sequence, ok := rotatedSuffix(stamp[stampLen:], ext)
+		if !ok {
+			continue
+		}
+
+		files = append(files, rotatedFile{
+			path:     filepath.Join(dir, name),
+			rotated:  rotated,
+			sequence: sequence,
+		})
+	}
+
+	slices.SortFunc(files, func(a, b rotatedFile) int {
+		if c := a.rotated.Compare(b.rotated); c != 0 {
+			return c
+		}
+		return a.sequence - b.sequence
+	})
+	return files, nil
+}
+
+// rotatedSuffix parses what follows the timestamp of a rotated file name,
+// which must be an optional ".N" sequence, the extension of the log file
+// and an optional ".gz", so that other files sharing the base name are
+// never taken as rotated ones.
+func rotatedSuffix(suffix, ext string) (int, bool) {
+	suffix = strings.TrimSuffix(suffix, ".gz")
+
+	rest, ok := strings.CutSuffix(suffix, ext)
+	if !ok {
+		return 0, false
+	}
+	if rest == "" {
+		return 0, true
+	}
+
+	digits, ok := strings.CutPrefix(rest, ".")
+	if !ok {
+		return 0, false
+	}
+
+	sequence, err := strconv.Atoi(digits)
+	if err != nil || sequence < 1 || strconv.Itoa(sequence) != digits {
+		return 0, false
+	}
+	return sequence, true
+}
+
+func (f *RotatingFile) retain() error {
+	if f.opts.MaxAge <= 0 && f.opts.MaxCount <= 0 {
+		return nil
+	}
+
+	files, err := f.rotated()
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, 0)
+	for i, file := range files {
+		remove := f.opts.MaxCount > 0 && len(files)-i > f.opts.MaxCount
+		if f.opts.MaxAge > 0 && time.Since(file.rotated) > f.opts.MaxAge {
+			remove = true
+		}
+
+		if remove {
+			if err := os.Remove(file.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func compressFile(path string) error {
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	target, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+
+	writer := gzip.NewWriter(target)
+	_, err = io.Copy(writer, source)
+	err = errors.Join(err, writer.Close(), target.Close())
+	if err != nil {
+		os.Remove(path + ".gz")
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,184 @@
+package log
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Rafael24595/go-web/router/configuration"
+)
+
+const (
+	STDOUT string = "stdout"
+	STDERR string = "stderr"
+)
+
+var (
+	sinksMu sync.Mutex
+	sinks   = make(map[string]io.Writer)
+)
+
+// LoggerOpts describes a logger in the terms of the GO_WEB_LOG_*
+// configuration keys.
+//
+//   - Level: minimum level (debug, info, warn or error).
+//   - Format: "plain" for the buffered "(GO-WEB)" lines, or "text" and
+//     "json" for log/slog output.
+//   - Sinks: outputs, each "stdout", "stderr" or a file path; stderr
+//     when empty.
+//   - Enable and Disable: categories logged at any level, or never.
+//   - Rotation: rotation and retention of the file sinks, and whether
+//     they are reopened on SIGHUP.
+type LoggerOpts struct {
+	Level    string
+	Format   string
+	Sinks    []string
+	Enable   []string
+	Disable  []string
+	Rotation RotationOpts
+}
+
+// ConfiguredOpts returns the LoggerOpts read from the global
+// configuration.
+func ConfiguredOpts() LoggerOpts {
+	config := configuration.Instance()
+	return LoggerOpts{
+		Level:   config.LogLevel(),
+		Format:  config.LogFormat(),
+		Sinks:   config.LogSinks(),
+		Enable:  config.LogEnable(),
+		Disable: config.LogDisable(),
+		Rotation: RotationOpts{
+			MaxSize:  config.LogMaxSize(),
+			Daily:    config.LogDaily(),
+			Compress: config.LogCompress(),
+			MaxAge:   config.LogMaxAge(),
+			MaxCount: config.LogMaxCount(),
+			Reopen:   config.LogReopen(),
+		},
+	}
+}
+
+// NewLogger builds a filtered logger from the given options. It returns
+// an error if the level or a sink is invalid.
+func NewLogger(opts LoggerOpts) (Log, error) {
+	level, err := ParseLevel(opts.Level)
+	if opts.Level == "" {
+		level, err = slog.LevelInfo, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	writer, err := sinkGroup(opts.Rotation, opts.Sinks...)
+	if err != nil {
+		return nil, err
+	}
+
+	var logger Log
+	switch strings.ToLower(opts.Format) {
+	case string(JSON), string(TEXT):
+		logger = NewSlogLogger(SlogOpts{
+			Format: Format(strings.ToLower(opts.Format)),
+			Level:  slog.LevelDebug,
+			Writer: writer,
+		})
+	default:
+		logger = NewAsyncLogger(AsyncOpts{
+			Writer: writer,
+		})
+	}
+
+	return NewFilter(logger).
+		Level(level).
+		Enable(opts.Enable...).
+		Disable(opts.Disable...), nil
+}
+
+// Sink returns the writer of an output: "stdout", "stderr" or a file
+// path, opened as a FileSink. A file is opened once and shared by every
+// logger using it.
+func Sink(name string) (io.Writer, error) {
+	return sink(name, RotationOpts{})
+}
+
+// Sinks returns a writer duplicating its output to every given sink, or
+// the standard error when none is given.
+func Sinks(names ...string) (io.Writer, error) {
+	return sinkGroup(RotationOpts{}, names...)
+}
+
+// FileSink opens a log file in append mode, creating it and its
+// directories when missing.
+func FileSink(path string) (io.WriteCloser, error) {
+	return RotatingFileSink(path, RotationOpts{})
+}
+
+// RotatingFileSink opens a log file like FileSink, rotated and retained
+// according to the given options. With Reopen, the file is reopened at
+// its path when the process receives SIGHUP, so that it can be rotated
+// by logrotate.
+func RotatingFileSink(path string, opts RotationOpts) (io.WriteCloser, error) {
+	file, err := NewRotatingFile(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Reopen {
+		file.ReopenOn()
+	}
+	return file, nil
+}
+
+// sink returns a standard output or a cached file sink. The rotation of
+// a file is defined by its first use.
+func sink(name string, rotation RotationOpts) (io.Writer, error) {
+	switch strings.ToLower(name) {
+	case STDOUT:
+		return os.Stdout, nil
+	case STDERR, "":
+		return os.Stderr, nil
+	}
+
+	path, err := filepath.Abs(name)
+	if err != nil {
+		return nil, err
+	}
+
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+
+	if sink, ok := sinks[path]; ok {
+		return sink, nil
+	}
+
+	sink, err := RotatingFileSink(path, rotation)
+	if err != nil {
+		return nil, err
+	}
+
+	sinks[path] = sink
+	return sink, nil
+}
+
+func sinkGroup(rotation RotationOpts, names ...string) (io.Writer, error) {
+	if len(names) == 0 {
+		return os.Stderr, nil
+	}
+
+	writers := make([]io.Writer, 0, len(names))
+	for _, name := range names {
+		writer, err := sink(name, rotation)
+		if err != nil {
+			return nil, err
+		}
+		writers = append(writers, writer)
+	}
+
+	if len(writers) == 1 {
+		return writers[0], nil
+	}
+	return io.MultiWriter(writers...), nil
+}
@@ -0,0 +1,242 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// packagePrefix identifies the frames of this package, which are skipped
+// when resolving the source of a record.
+const packagePrefix = "github.com/Rafael24595/go-web/router/log."
+
+// Format selects the output of the handler built by NewSlogLogger.
+type Format string
+
+const (
+	TEXT Format = "text"
+	JSON Format = "json"
+)
+
+// Attribute keys used by the router when logging requests.
+const (
+	CATEGORY   string = "category"
+	METHOD     string = "method"
+	ROUTE      string = "route"
+	PATH       string = "path"
+	STATUS     string = "status"
+	LATENCY    string = "latency"
+	REMOTE     string = "remote"
+	REQUEST_ID string = "request_id"
+)
+
+// StructuredLog is a Log that also records key-value attributes.
+type StructuredLog interface {
+	Log
+	Attrs(category string, message string, attrs ...slog.Attr)
+	With(attrs ...slog.Attr) StructuredLog
+}
+
+// SlogOpts configures the logger built by NewSlogLogger.
+//
+// Writer defaults to os.Stdout, Format to TEXT and Level to slog.LevelInfo.
+// AddSource reports the code that called the logger, outside this package.
+// Attrs are added to every record.
+type SlogOpts struct {
+	Format    Format
+	Level     slog.Leveler
+	Writer    io.Writer
+	AddSource bool
+	Attrs     []slog.Attr
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+	source bool
+}
+
+// NewSlogLogger creates a Log writing records through a log/slog text or
+// JSON handler.
+//
+// Example:
+//
+//	logger := log.NewSlogLogger(log.SlogOpts{
+//	    Format: log.JSON,
+//	    Level:  slog.LevelWarn,
+//	    Attrs:  []slog.Attr{slog.String("service", "catalog")},
+//	})
+//	router.NewRouter().Logger(logger)
+func NewSlogLogger(opts SlogOpts) StructuredLog {
+	writer := opts.Writer
+	if writer == nil {
+		writer = os.Stdout
+	}
+
+	handlerOpts := &slog.HandlerOptions{
+		Level:     opts.Level,
+		AddSource: opts.AddSource,
+	}
+
+	var handler slog.Handler
+	if opts.Format == JSON {
+		handler = slog.NewJSONHandler(writer, handlerOpts)
+	} else {
+		handler = slog.NewTextHandler(writer, handlerOpts)
+	}
+
+	if len(opts.Attrs) > 0 {
+		handler = handler.WithAttrs(opts.Attrs)
+	}
+
+	return &slogLogger{
+		logger: slog.New(handler),
+		source: opts.AddSource,
+	}
+}
+
+// SlogLogger adapts an existing *slog.Logger to Log, so it can be passed
+// to Router.Logger or OpenAPI3Viewer.Logger. MESSAGE records are logged at
+// info level, WARNING at warn and ERROR at error; other categories are
+// logged at their CategoryLevel with a category attribute. The caller of
+// every record is resolved, since the handler may report its source.
+func SlogLogger(logger *slog.Logger) StructuredLog {
+	return &slogLogger{
+		logger: logger,
+		source: true,
+	}
+}
+
+// CategoryLevel returns the slog level of a log category: debug for DEBUG,
+// warn for WARNING, error for ERROR and info for any other.
+func CategoryLevel(category string) slog.Level {
+	switch strings.ToUpper(category) {
+	case DEBUG:
+		return slog.LevelDebug
+	case WARNING:
+		return slog.LevelWarn
+	case ERROR:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// Attrs records a message with attributes when the logger supports them,
+// and only the message otherwise.
+func Attrs(logger Log, category string, message string, attrs ...slog.Attr) {
+	if structured, ok := logger.(StructuredLog); ok {
+		structured.Attrs(category, message, attrs...)
+		return
+	}
+	logger.Custom(category, message)
+}
+
+func (l *slogLogger) Attrs(category string, message string, attrs ...slog.Attr) {
+	l.record(strings.ToUpper(category), message, attrs...)
+}
+
+func (l *slogLogger) With(attrs ...slog.Attr) StructuredLog {
+	args := make([]any, len(attrs))
+	for i, attr := range attrs {
+		args[i] = attr
+	}
+	return &slogLogger{
+		logger: l.logger.With(args...),
+		source: l.source,
+	}
+}
+
+func (l *slogLogger) Custom(category string, message string) {
+	l.Attrs(category, message)
+}
+
+func (l *slogLogger) Custome(category string, err error) {
+	l.Attrs(category, err.Error())
+}
+
+func (l *slogLogger) Customf(category string, format string, args ...any) {
+	l.Attrs(category, fmt.Sprintf(format, args...))
+}
+
+func (l *slogLogger) Message(message string) {
+	l.record(MESSAGE, message)
+}
+
+func (l *slogLogger) Messagef(format string, args ...any) {
+	l.record(MESSAGE, fmt.Sprintf(format, args...))
+}
+
+func (l *slogLogger) Warning(message string) {
+	l.record(WARNING, message)
+}
+
+func (l *slogLogger) Warningf(format string, args ...any) {
+	l.record(WARNING, fmt.Sprintf(format, args...))
+}
+
+func (l *slogLogger) Error(err error) {
+	l.record(ERROR, err.Error())
+}
+
+func (l *slogLogger) Errors(message string) {
+	l.record(ERROR, message)
+}
+
+func (l *slogLogger) Errorf(format string, args ...any) {
+	l.record(ERROR, fmt.Sprintf(format, args...))
+}
+
+func (l *slogLogger) Write(slice []byte) (n int, err error) {
+	l.record(WARNING, strings.TrimSpace(string(slice)))
+	return len(slice), nil
+}
+
+func (l *slogLogger) record(category string, message string, attrs ...slog.Attr) {
+	level := CategoryLevel(category)
+
+	ctx := context.Background()
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	switch category {
+	case MESSAGE, WARNING, ERROR:
+	default:
+		attrs = append([]slog.Attr{slog.String(CATEGORY, category)}, attrs...)
+	}
+
+	var pc uintptr
+	if l.source {
+		pc = callerPC()
+	}
+
+	record := slog.NewRecord(time.Now(), level, message, pc)
+	record.AddAttrs(attrs...)
+	_ = l.logger.Handler().Handle(ctx, record)
+}
+
+// callerPC returns the program counter of the first caller outside this
+// package, so the source of a record points to the code that logged it
+// instead of the wrappers it went through.
+func callerPC() uintptr {
+	var pcs [32]uintptr
+	n := runtime.Callers(2, pcs[:])
+
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" && !strings.HasPrefix(frame.Function, packagePrefix) {
+			// Frame.PC points into the call instruction, while a record
+			// expects the return address that runtime.Callers reported.
+			return frame.PC + 1
+		}
+		if !more {
+			return 0
+		}
+	}
+}
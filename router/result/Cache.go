@@ -0,0 +1,90 @@
+package result
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WithETag returns a copy of the Result carrying a strong entity tag built
+// from the given version, such as a revision number or content hash.
+//
+// The router answers conditional requests against it: 304 Not Modified
+// for a matching If-None-Match on GET and HEAD, and 412 Precondition
+// Failed for a mismatching If-Match.
+func (r Result) WithETag(version string) Result {
+	return r.WithHeader("ETag", FormatETag(version, false))
+}
+
+// WithWeakETag returns a copy of the Result carrying a weak entity tag,
+// for representations that are equivalent but not byte-identical.
+func (r Result) WithWeakETag(version string) Result {
+	return r.WithHeader("ETag", FormatETag(version, true))
+}
+
+// WithAutoETag returns a copy of the Result whose strong entity tag is a
+// hash of the encoded payload, computed by the router after encoding. It
+// has no effect on streams and files, and an explicit ETag takes
+// precedence.
+func (r Result) WithAutoETag() Result {
+	r.autoETag = true
+	return r
+}
+
+// WithLastModified returns a copy of the Result with the Last-Modified
+// header, evaluated against If-Modified-Since and If-Unmodified-Since.
+func (r Result) WithLastModified(modified time.Time) Result {
+	return r.WithHeader("Last-Modified", modified.UTC().Format(http.TimeFormat))
+}
+
+// ETag returns the entity tag of the Result, or an empty string.
+func (r Result) ETag() string {
+	return r.headers.Get("ETag")
+}
+
+// AutoETag reports whether the entity tag is hashed from the encoded payload.
+func (r Result) AutoETag() bool {
+	return r.autoETag
+}
+
+// ResolveETag returns a copy of the Result with the entity tag hashed from
+// the encoded content when WithAutoETag was requested and no explicit tag
+// was set.
+func (r Result) ResolveETag(content []byte) Result {
+	if !r.autoETag || r.ETag() != "" {
+		return r
+	}
+	return r.WithHeader("ETag", HashETag(content))
+}
+
+// LastModified returns the modification time of the Result, or the zero
+// time if none was set.
+func (r Result) LastModified() time.Time {
+	modified, err := http.ParseTime(r.headers.Get("Last-Modified"))
+	if err != nil {
+		return time.Time{}
+	}
+	return modified
+}
+
+// FormatETag quotes a version as an entity tag, prefixed with W/ when
+// weak. Already formatted tags are returned unchanged.
+func FormatETag(version string, weak bool) string {
+	if strings.HasPrefix(version, `"`) || strings.HasPrefix(version, `W/"`) {
+		return version
+	}
+
+	tag := `"` + version + `"`
+	if weak {
+		return "W/" + tag
+	}
+	return tag
+}
+
+// HashETag returns a strong entity tag derived from the given content.
+func HashETag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return FormatETag(base64.RawURLEncoding.EncodeToString(sum[:16]), false)
+}
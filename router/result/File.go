@@ -0,0 +1,167 @@
+package result
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileContent is an opened file result, served by the router with
+// http.ServeContent. Name is used to detect the content type when none
+// was set on the Result, and a zero Modified omits Last-Modified.
+type FileContent struct {
+	Name     string
+	Modified time.Time
+	Content  io.ReadSeeker
+}
+
+// Close closes the content when it is an io.Closer.
+func (f FileContent) Close() error {
+	if closer, ok := f.Content.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// FileOpener opens the content of a file result when the response is
+// written. Errors wrapping fs.ErrNotExist or fs.ErrInvalid are answered
+// with 404 Not Found and fs.ErrPermission with 403 Forbidden.
+type FileOpener = func() (FileContent, error)
+
+// FileFS returns a file result read from the given file system, such as an
+// embed.FS or os.DirFS. The name may come from the request: a leading
+// slash is ignored and any name escaping the file system root, like
+// "../secret", is answered with 404 Not Found.
+//
+// Range requests are answered with 206 Partial Content.
+func FileFS(fsys fs.FS, name string) Result {
+	return FileFunc(func() (FileContent, error) {
+		name := strings.TrimPrefix(name, "/")
+		if !fs.ValidPath(name) {
+			return FileContent{}, fmt.Errorf("invalid file path '%s': %w", name, fs.ErrInvalid)
+		}
+
+		file, err := fsys.Open(name)
+		if err != nil {
+			return FileContent{}, err
+		}
+
+		return fileContent(file, name)
+	})
+}
+
+// FileDir returns a file result read from a directory of the operating
+// system. The file is opened with os.OpenInRoot, so neither relative
+// paths nor symbolic links can escape the directory.
+func FileDir(dir, name string) Result {
+	return FileFunc(func() (FileContent, error) {
+		name := strings.TrimPrefix(name, "/")
+		if !filepath.IsLocal(name) {
+			return FileContent{}, fmt.Errorf("invalid file path '%s': %w", name, fs.ErrInvalid)
+		}
+
+		file, err := os.OpenInRoot(dir, name)
+		if err != nil {
+			return FileContent{}, err
+		}
+
+		return fileContent(file, name)
+	})
+}
+
+// FileReader returns a file result serving the given content. The reader
+// is closed after the response if it is an io.Closer.
+func FileReader(name string, content io.ReadSeeker, modified time.Time) Result {
+	return FileFunc(func() (FileContent, error) {
+		return FileContent{
+			Name:     name,
+			Modified: modified,
+			Content:  content,
+		}, nil
+	})
+}
+
+// FileBytes returns a file result serving the given bytes.
+func FileBytes(name string, content []byte) Result {
+	return FileReader(name, bytes.NewReader(content), time.Time{})
+}
+
+// FileFunc returns a file result whose content is opened by the given
+// function when the response is written.
+func FileFunc(open FileOpener) Result {
+	return Result{
+		ignore:  false,
+		isOk:    true,
+		isFile:  true,
+		status:  http.StatusOK,
+		payload: nil,
+		encoder: NewTextEncoder(),
+		file:    open,
+	}
+}
+
+// WithAttachment returns a copy of the Result that asks the client to
+// download the body as a file with the given name.
+func (r Result) WithAttachment(filename string) Result {
+	return r.WithHeader("Content-Disposition", disposition("attachment", filename))
+}
+
+// WithInline returns a copy of the Result that asks the client to display
+// the body, suggesting the given name if it is saved.
+func (r Result) WithInline(filename string) Result {
+	return r.WithHeader("Content-Disposition", disposition("inline", filename))
+}
+
+// FileOpener returns the function opening the content of the Result, or
+// nil if the Result is not a file or was built with FileOk.
+func (r Result) FileOpener() FileOpener {
+	return r.file
+}
+
+func disposition(kind, filename string) string {
+	if filename == "" {
+		return kind
+	}
+	return mime.FormatMediaType(kind, map[string]string{"filename": filename})
+}
+
+func fileContent(file fs.File, name string) (FileContent, error) {
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return FileContent{}, err
+	}
+
+	if info.IsDir() {
+		file.Close()
+		return FileContent{}, fmt.Errorf("'%s' is a directory: %w", name, fs.ErrNotExist)
+	}
+
+	if seeker, ok := file.(io.ReadSeeker); ok {
+		return FileContent{
+			Name:     info.Name(),
+			Modified: info.ModTime(),
+			Content:  seeker,
+		}, nil
+	}
+
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return FileContent{}, err
+	}
+
+	return FileContent{
+		Name:     info.Name(),
+		Modified: info.ModTime(),
+		Content:  bytes.NewReader(content),
+	}, nil
+}
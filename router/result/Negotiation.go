@@ -0,0 +1,192 @@
+package result
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = []registeredEncoder{
+		{media: "application/json", encoder: NewJsonEncoder()},
+		{media: "application/xml", encoder: NewXmlEncoder()},
+		{media: "text/xml", encoder: NewXmlEncoder()},
+	}
+)
+
+type registeredEncoder struct {
+	media   string
+	encoder ResultEncoder
+}
+
+type acceptRange struct {
+	kind    string
+	subtype string
+	quality float64
+}
+
+// RegisterEncoder registers an encoder for the given media type so it can
+// be selected by Negotiate, replacing any encoder previously registered
+// for it. When the client accepts several types with the same quality,
+// the one registered first wins.
+func RegisterEncoder(media string, encoder ResultEncoder) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+
+	for i, v := range encoders {
+		if v.media == media {
+			encoders[i].encoder = encoder
+			return
+		}
+	}
+
+	encoders = append(encoders, registeredEncoder{
+		media:   media,
+		encoder: encoder,
+	})
+}
+
+// Encoders returns the media types available for negotiation, in
+// registration order.
+func Encoders() []string {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	media := make([]string, len(encoders))
+	for i, v := range encoders {
+		media[i] = v.media
+	}
+	return media
+}
+
+// Negotiate returns a successful result with HTTP 200 whose encoder is
+// chosen by the Router from the request Accept header.
+//
+// Example:
+//
+//	func handler(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+//	    return result.Negotiate(user) // JSON or XML, as the client prefers
+//	}
+func Negotiate(payload any) Result {
+	return Negotiates(http.StatusOK, payload)
+}
+
+// Negotiates returns a successful result with a custom HTTP status whose
+// encoder is chosen by the Router from the request Accept header.
+func Negotiates(status int, payload any) Result {
+	return Result{
+		ignore:    false,
+		isOk:      true,
+		isFile:    false,
+		status:    status,
+		payload:   payload,
+		encoder:   NewJsonEncoder(),
+		negotiate: true,
+	}
+}
+
+// Negotiable reports whether the encoder of the Result must be chosen
+// from the request Accept header.
+func (r Result) Negotiable() bool {
+	return r.negotiate
+}
+
+// Resolve returns a copy of the Result encoded with the registered encoder
+// that best matches the given Accept header, using its q-values. An empty
+// header accepts any type.
+//
+// It returns false when none of the registered media types is acceptable.
+func (r Result) Resolve(accept string) (Result, bool) {
+	media, encoder, ok := NegotiateEncoder(accept)
+	if !ok {
+		return r, false
+	}
+
+	r.encoder = encoder
+	r.negotiate = false
+	return r.WithContentType(media), true
+}
+
+// NegotiateEncoder returns the registered media type and encoder that best
+// match the given Accept header.
+func NegotiateEncoder(accept string) (string, ResultEncoder, bool) {
+	ranges := parseAccept(accept)
+
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	best := -1
+	quality := 0.0
+	for i, v := range encoders {
+		if q := matchAccept(ranges, v.media); q > quality {
+			best, quality = i, q
+		}
+	}
+
+	if best < 0 {
+		return "", nil, false
+	}
+
+	return encoders[best].media, encoders[best].encoder, true
+}
+
+func parseAccept(accept string) []acceptRange {
+	if strings.TrimSpace(accept) == "" {
+		return []acceptRange{{kind: "*", subtype: "*", quality: 1}}
+	}
+
+	ranges := make([]acceptRange, 0)
+	for _, item := range strings.Split(accept, ",") {
+		media, params, err := mime.ParseMediaType(strings.TrimSpace(item))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if raw, ok := params["q"]; ok {
+			value, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				continue
+			}
+			quality = value
+		}
+
+		kind, subtype, _ := strings.Cut(media, "/")
+		ranges = append(ranges, acceptRange{
+			kind:    kind,
+			subtype: subtype,
+			quality: quality,
+		})
+	}
+
+	return ranges
+}
+
+// matchAccept returns the quality of the most specific range matching the
+// media type, or 0 if it is not acceptable.
+func matchAccept(ranges []acceptRange, media string) float64 {
+	kind, subtype, _ := strings.Cut(media, "/")
+
+	specificity := -1
+	quality := 0.0
+	for _, r := range ranges {
+		current := -1
+		switch {
+		case r.kind == kind && r.subtype == subtype:
+			current = 2
+		case r.kind == kind && r.subtype == "*":
+			current = 1
+		case r.kind == "*" && r.subtype == "*":
+			current = 0
+		}
+
+		if current > specificity {
+			specificity, quality = current, r.quality
+		}
+	}
+
+	return quality
+}
@@ -0,0 +1,181 @@
+package result
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+
+	"github.com/Rafael24595/go-collections/collection"
+)
+
+// PROBLEM_TYPE_DEFAULT is the problem type used when none is provided,
+// meaning the problem has no semantics beyond its HTTP status.
+const PROBLEM_TYPE_DEFAULT = "about:blank"
+
+// Problem represents a "problem details" error body as defined by
+// RFC 9457 (formerly RFC 7807).
+//
+// Extensions are serialized as additional top-level members; they can not
+// override the standard members.
+type Problem struct {
+	Type       string         `json:"type" description:"URI reference identifying the problem type"`
+	Title      string         `json:"title" description:"Short summary of the problem type"`
+	Status     int            `json:"status" description:"HTTP status code"`
+	Detail     string         `json:"detail,omitempty" description:"Explanation specific to this occurrence"`
+	Instance   string         `json:"instance,omitempty" description:"URI reference identifying this occurrence"`
+	Extensions map[string]any `json:"-"`
+}
+
+// NewProblem creates a Problem for the given status, using the default
+// type and the standard status text as title.
+func NewProblem(status int, detail string) Problem {
+	return Problem{
+		Type:       PROBLEM_TYPE_DEFAULT,
+		Title:      http.StatusText(status),
+		Status:     status,
+		Detail:     detail,
+		Instance:   "",
+		Extensions: make(map[string]any),
+	}
+}
+
+// ProblemFromErr creates a Problem for the given status whose detail
+// contains the non-nil error messages, one per line.
+func ProblemFromErr(status int, err ...error) Problem {
+	return NewProblem(status, joinErrors(err))
+}
+
+// WithType returns a copy of the Problem with the given type URI.
+func (p Problem) WithType(kind string) Problem {
+	p.Type = kind
+	return p
+}
+
+// WithTitle returns a copy of the Problem with the given title.
+func (p Problem) WithTitle(title string) Problem {
+	p.Title = title
+	return p
+}
+
+// WithDetail returns a copy of the Problem with the given detail.
+func (p Problem) WithDetail(detail string) Problem {
+	p.Detail = detail
+	return p
+}
+
+// WithInstance returns a copy of the Problem with the given instance URI.
+func (p Problem) WithInstance(instance string) Problem {
+	p.Instance = instance
+	return p
+}
+
+// WithExtension returns a copy of the Problem with an additional member.
+func (p Problem) WithExtension(key string, value any) Problem {
+	extensions := make(map[string]any, len(p.Extensions)+1)
+	maps.Copy(extensions, p.Extensions)
+	extensions[key] = value
+
+	p.Extensions = extensions
+	return p
+}
+
+// Error returns the problem title and detail, so a Problem can be used
+// where an error is expected.
+func (p Problem) Error() string {
+	if p.Detail == "" {
+		return p.Title
+	}
+	return p.Title + ": " + p.Detail
+}
+
+// MarshalJSON serializes the standard members together with the extensions.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]any, len(p.Extensions)+5)
+	maps.Copy(members, p.Extensions)
+
+	members["type"] = p.Type
+	if p.Type == "" {
+		members["type"] = PROBLEM_TYPE_DEFAULT
+	}
+	members["title"] = p.Title
+	members["status"] = p.Status
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	}
+
+	return json.Marshal(members)
+}
+
+// UnmarshalJSON parses a problem body, collecting unknown members as extensions.
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	type standard Problem
+
+	var problem standard
+	if err := json.Unmarshal(data, &problem); err != nil {
+		return err
+	}
+
+	members := make(map[string]any)
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	for _, k := range []string{"type", "title", "status", "detail", "instance"} {
+		delete(members, k)
+	}
+
+	*p = Problem(problem)
+	p.Extensions = members
+
+	return nil
+}
+
+type problemEncoder struct{}
+
+// NewProblemEncoder creates a new application/problem+json encoder.
+func NewProblemEncoder() ResultEncoder {
+	return &problemEncoder{}
+}
+
+// Encode serializes the payload as JSON.
+func (e *problemEncoder) Encode(payload any) ([]byte, error) {
+	return NewJsonEncoder().Encode(payload)
+}
+
+// Headers returns the HTTP Content-Type header for problem responses.
+func (e *problemEncoder) Headers() map[string]string {
+	return map[string]string{
+		"Content-Type": "application/problem+json",
+	}
+}
+
+// ProblemErr returns an application/problem+json error result with the
+// given status, whose detail contains the error messages.
+func ProblemErr(status int, err ...error) Result {
+	return FromProblem(ProblemFromErr(status, err...))
+}
+
+// FromProblem returns an application/problem+json error result for the
+// given Problem, using its status as the HTTP status.
+func FromProblem(problem Problem) Result {
+	return Result{
+		ignore:  false,
+		isOk:    false,
+		isFile:  false,
+		status:  problem.Status,
+		payload: problem,
+		encoder: NewProblemEncoder(),
+	}
+}
+
+func joinErrors(err []error) string {
+	raw := collection.VectorFromList(err)
+	raw.FilterSelf(func(err error) bool { return err != nil })
+
+	return collection.VectorMap(raw, func(e error) string {
+		return e.Error()
+	}).Join("\n")
+}
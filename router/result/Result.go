@@ -2,8 +2,6 @@ package result
 
 import (
 	"net/http"
-
-	"github.com/Rafael24595/go-collections/collection"
 )
 
 // Result represents the outcome of a route handler execution.
@@ -13,6 +11,11 @@ import (
 //   - HTTP status code (`status`)
 //   - Response payload (`payload`)
 //   - Encoder (`encoder`) to format the response
+//   - Additional response headers (`headers`) and cookies (`cookies`)
+//   - Whether the encoder is chosen from the Accept header (`negotiate`)
+//   - Function writing a streamed body (`stream`)
+//   - Whether the ETag is hashed from the encoded body (`autoETag`)
+//   - Function opening the content of a file result (`file`)
 type Result struct {
 	ignore  bool
 	isOk    bool
@@ -20,6 +23,13 @@ type Result struct {
 	status  int
 	payload any
 	encoder ResultEncoder
+	headers http.Header
+	cookies []*http.Cookie
+
+	negotiate bool
+	stream    StreamWriter
+	autoETag  bool
+	file      FileOpener
 }
 
 // Ok returns a successful plain-text result with HTTP 200.
@@ -70,7 +80,11 @@ func XmlOk(payload any) Result {
 	}
 }
 
-// FileOk returns a successful File result with HTTP 200.
+// FileOk returns a successful File result with HTTP 200, serving the
+// file at the given path.
+//
+// The path is served as is, so it must not be built from user input;
+// use FileDir or FileFS instead.
 func FileOk(payload any) Result {
 	return Result{
 		ignore:  false,
@@ -147,14 +161,10 @@ func CustomOks(status int, payload any, encoder ResultEncoder) Result {
 	}
 }
 
-// Err returns a plain-text error result with a given HTTP status.
+// Err returns a plain-text error result with a given HTTP status,
+// with one line per non-nil error.
 func Err(status int, err ...error) Result {
-	raw := collection.VectorFromList(err)
-	raw.FilterSelf(func(err error) bool { return err != nil })
-	
-	message := collection.VectorMap(raw, func(e error) string {
-		return e.Error()
-	}).Join("/n")
+	message := joinErrors(err)
 
 	return Result{
 		ignore:  false,
@@ -281,3 +291,52 @@ func (r Result) Err() bool {
 func (r Result) File() bool {
 	return r.isFile
 }
+
+// WithHeader returns a copy of the Result that sets the given response
+// header, replacing any previous value. Headers set this way take
+// precedence over the ones provided by the encoder.
+//
+// Example:
+//
+//	return result.JsonOks(http.StatusCreated, user).
+//	    WithHeader("Location", "/users/"+user.Id)
+func (r Result) WithHeader(key, value string) Result {
+	headers := r.Headers()
+	headers.Set(key, value)
+	r.headers = headers
+	return r
+}
+
+// WithCookie returns a copy of the Result that sets the given cookie
+// on the response.
+func (r Result) WithCookie(cookie *http.Cookie) Result {
+	cookies := make([]*http.Cookie, 0, len(r.cookies)+1)
+	cookies = append(cookies, r.cookies...)
+	r.cookies = append(cookies, cookie)
+	return r
+}
+
+// WithContentType returns a copy of the Result whose Content-Type
+// overrides the one provided by the encoder.
+func (r Result) WithContentType(contentType string) Result {
+	return r.WithHeader("Content-Type", contentType)
+}
+
+// Headers returns a copy of the additional response headers of the Result.
+func (r Result) Headers() http.Header {
+	if r.headers == nil {
+		return make(http.Header)
+	}
+	return r.headers.Clone()
+}
+
+// Cookies returns the cookies to be set on the response.
+func (r Result) Cookies() []*http.Cookie {
+	return r.cookies
+}
+
+// Streamer returns the function writing the streamed body of the Result,
+// or nil if the Result is not a stream.
+func (r Result) Streamer() StreamWriter {
+	return r.stream
+}
@@ -0,0 +1,285 @@
+package result
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// StreamWriter writes a streamed response body. It is called by the
+// Router after the status and headers have been sent, and should stop as
+// soon as the request context is done.
+type StreamWriter = func(wrt http.ResponseWriter, req *http.Request) error
+
+// Event is a single Server-Sent Event.
+//
+// Data is sent as is when it is a string or a byte slice, and encoded as
+// JSON otherwise. Multi-line data is split into several `data` fields.
+type Event struct {
+	ID    string
+	Event string
+	Data  any
+	Retry time.Duration
+}
+
+// SSEOpts configures a Server-Sent Events stream.
+type SSEOpts struct {
+	Retry     time.Duration // Reconnection delay advertised to the client (0 omits it)
+	Heartbeat time.Duration // Interval of keep-alive comments (0 disables them)
+}
+
+type streamEncoder struct {
+	headers map[string]string
+}
+
+// Encode returns an empty body, since streamed results are written by
+// their StreamWriter.
+func (e *streamEncoder) Encode(payload any) ([]byte, error) {
+	return make([]byte, 0), nil
+}
+
+// Headers returns the HTTP headers of the stream.
+func (e *streamEncoder) Headers() map[string]string {
+	return e.headers
+}
+
+// StreamFunc returns a successful result with HTTP 200 whose body is
+// written by the given function, flushing as it goes.
+func StreamFunc(contentType string, writer StreamWriter) Result {
+	return Result{
+		ignore:  false,
+		isOk:    true,
+		isFile:  false,
+		status:  http.StatusOK,
+		payload: nil,
+		encoder: &streamEncoder{
+			headers: map[string]string{
+				"Content-Type": contentType,
+			},
+		},
+		stream: writer,
+	}
+}
+
+// Stream returns a successful result with HTTP 200 that copies the reader
+// into the response as application/octet-stream, flushing every chunk.
+// If the reader is an io.Closer it is closed once the copy ends.
+//
+// Use WithContentType to change the media type.
+func Stream(reader io.Reader) Result {
+	return StreamFunc("application/octet-stream", func(wrt http.ResponseWriter, req *http.Request) error {
+		if closer, ok := reader.(io.Closer); ok {
+			defer closer.Close()
+		}
+
+		controller := http.NewResponseController(wrt)
+		buffer := make([]byte, 32*1024)
+		for {
+			if err := req.Context().Err(); err != nil {
+				return err
+			}
+
+			n, err := reader.Read(buffer)
+			if n > 0 {
+				if _, err := wrt.Write(buffer[:n]); err != nil {
+					return err
+				}
+				flush(controller)
+			}
+
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// SSE returns a Server-Sent Events result that sends every event received
+// from the channel until it is closed or the client disconnects.
+//
+// Example:
+//
+//	func handler(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+//	    return result.SSE(notifications.Subscribe(r.Context()))
+//	}
+func SSE(events <-chan Event) Result {
+	return SSEWithOpts(events, SSEOpts{})
+}
+
+// SSEWithOpts returns a Server-Sent Events result with additional options.
+func SSEWithOpts(events <-chan Event, opts SSEOpts) Result {
+	return SSEResume(func(string) <-chan Event {
+		return events
+	}, opts)
+}
+
+// SSEResume returns a Server-Sent Events result whose channel is built
+// from the Last-Event-ID header sent by reconnecting clients, so the
+// source can resume after the last event they received. The header is
+// empty on the first connection.
+func SSEResume(source func(lastEventID string) <-chan Event, opts SSEOpts) Result {
+	result := StreamFunc("text/event-stream", func(wrt http.ResponseWriter, req *http.Request) error {
+		return writeEvents(wrt, req, source(req.Header.Get("Last-Event-ID")), opts)
+	})
+
+	return result.
+		WithHeader("Cache-Control", "no-cache").
+		WithHeader("X-Accel-Buffering", "no")
+}
+
+// NDJSON returns a result that writes every item received from the
+// channel as a line of newline-delimited JSON, until the channel is
+// closed or the client disconnects, even while the channel is idle.
+func NDJSON[T any](items <-chan T) Result {
+	return StreamFunc("application/x-ndjson", func(wrt http.ResponseWriter, req *http.Request) error {
+		controller := http.NewResponseController(wrt)
+		encoder := json.NewEncoder(wrt)
+		ctx := req.Context()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case item, ok := <-items:
+				if !ok {
+					return nil
+				}
+				if err := encoder.Encode(item); err != nil {
+					return err
+				}
+				flush(controller)
+			}
+		}
+	})
+}
+
+// NDJSONSeq returns a result that writes every item of the sequence as a
+// line of newline-delimited JSON, stopping early if the client disconnects.
+// The disconnection is noticed when the next item is yielded, so sequences
+// that may wait for items should watch the request context themselves.
+func NDJSONSeq[T any](items iter.Seq[T]) Result {
+	return StreamFunc("application/x-ndjson", func(wrt http.ResponseWriter, req *http.Request) error {
+		controller := http.NewResponseController(wrt)
+		encoder := json.NewEncoder(wrt)
+		ctx := req.Context()
+
+		var err error
+		items(func(item T) bool {
+			if err = ctx.Err(); err != nil {
+				return false
+			}
+			if err = encoder.Encode(item); err != nil {
+				return false
+			}
+			flush(controller)
+			return true
+		})
+
+		return err
+	})
+}
+
+func writeEvents(wrt http.ResponseWriter, req *http.Request, events <-chan Event, opts SSEOpts) error {
+	controller := http.NewResponseController(wrt)
+	ctx := req.Context()
+
+	if opts.Retry > 0 {
+		if _, err := fmt.Fprintf(wrt, "retry: %d\n\n", opts.Retry.Milliseconds()); err != nil {
+			return err
+		}
+	}
+	flush(controller)
+
+	var heartbeat <-chan time.Time
+	if opts.Heartbeat > 0 {
+		ticker := time.NewTicker(opts.Heartbeat)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-heartbeat:
+			if _, err := io.WriteString(wrt, ": heartbeat\n\n"); err != nil {
+				return err
+			}
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			raw, err := event.encode()
+			if err != nil {
+				return err
+			}
+			if _, err := wrt.Write(raw); err != nil {
+				return err
+			}
+		}
+
+		flush(controller)
+	}
+}
+
+func (e Event) encode() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	if e.ID != "" {
+		fmt.Fprintf(&buffer, "id: %s\n", singleLine(e.ID))
+	}
+	if e.Event != "" {
+		fmt.Fprintf(&buffer, "event: %s\n", singleLine(e.Event))
+	}
+	if e.Retry > 0 {
+		fmt.Fprintf(&buffer, "retry: %d\n", e.Retry.Milliseconds())
+	}
+
+	var data string
+	switch v := e.Data.(type) {
+	case nil:
+	case string:
+		data = v
+	case []byte:
+		data = string(v)
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("Error marshalling event to JSON: %s", err.Error())
+		}
+		data = string(raw)
+	}
+
+	data = lineBreaks.Replace(data)
+	for line := range strings.Lines(data) {
+		fmt.Fprintf(&buffer, "data: %s\n", strings.TrimSuffix(line, "\n"))
+	}
+	if data == "" {
+		buffer.WriteString("data: \n")
+	}
+
+	buffer.WriteString("\n")
+	return buffer.Bytes(), nil
+}
+
+// lineBreaks turns the CRLF and CR line endings accepted by the event
+// stream format into LF, so data is split on all of them.
+var lineBreaks = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+
+func singleLine(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
+
+func flush(controller *http.ResponseController) {
+	_ = controller.Flush()
+}
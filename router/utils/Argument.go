@@ -3,6 +3,7 @@ package utils
 import (
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Argument struct {
@@ -93,3 +94,35 @@ func (a Argument) Float64d(def float64) float64 {
 	}
 	return def
 }
+
+func (a Argument) Duration() (time.Duration, bool) {
+	val, err := time.ParseDuration(a.item)
+	if err != nil {
+		return 0, false
+	}
+	return val, true
+}
+
+func (a Argument) Durationd(def time.Duration) time.Duration {
+	if res, ok := a.Duration(); ok {
+		return res
+	}
+	return def
+}
+
+func (a Argument) Stringd(def string) string {
+	if a.item == "" {
+		return def
+	}
+	return a.item
+}
+
+func (a Argument) List() []string {
+	list := make([]string, 0)
+	for item := range strings.SplitSeq(a.item, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
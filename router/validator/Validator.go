@@ -0,0 +1,374 @@
+package validator
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// TAG is the struct tag read by Validate.
+const TAG = "validate"
+
+const (
+	OMITEMPTY = "omitempty"
+	REQUIRED  = "required"
+	MIN       = "min"
+	MAX       = "max"
+	LEN       = "len"
+	EMAIL     = "email"
+	ONEOF     = "oneof"
+	PATTERN   = "pattern"
+)
+
+var patterns sync.Map
+
+// Rule is a single constraint declared in a `validate` tag.
+type Rule struct {
+	Name  string
+	Param string
+}
+
+// FieldError describes a field that does not satisfy one of its rules.
+//
+// Field is the path of the field inside the payload, built from the
+// serialization tags (json, xml, form or query) or the field name, e.g.
+// "address.lines[1]".
+type FieldError struct {
+	Field   string `json:"field" xml:"field"`
+	Rule    string `json:"rule" xml:"rule"`
+	Param   string `json:"param,omitempty" xml:"param,omitempty"`
+	Message string `json:"message" xml:"message"`
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Errors is the list of fields that failed validation.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, v := range e {
+		messages[i] = v.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Rules parses a `validate` tag into its rules.
+//
+// Rules are separated by commas and parameters follow an equal sign.
+// Because regular expressions may contain commas, `pattern` must be the
+// last rule of the tag and takes the rest of it as its parameter.
+func Rules(tag string) []Rule {
+	rules := make([]Rule, 0)
+
+	for tag != "" {
+		var item string
+		if strings.HasPrefix(tag, PATTERN+"=") {
+			item, tag = tag, ""
+		} else {
+			item, tag, _ = strings.Cut(tag, ",")
+		}
+
+		name, param, _ := strings.Cut(strings.TrimSpace(item), "=")
+		if name == "" {
+			continue
+		}
+
+		rules = append(rules, Rule{
+			Name:  name,
+			Param: param,
+		})
+	}
+
+	return rules
+}
+
+// Validate checks the payload against the rules declared in its
+// `validate` tags, walking nested structs, pointers and slices.
+//
+// Supported rules:
+//   - required: the value must not be zero, nil or empty.
+//   - omitempty: skips the remaining rules when the value is zero.
+//   - min, max, len: length for strings (in characters), slices and maps;
+//     value for numbers.
+//   - email: the value must be a bare e-mail address.
+//   - oneof: the value must be one of the space-separated options.
+//   - pattern: the value must match the regular expression.
+//
+// It returns Errors when one or more fields are invalid, or a plain error
+// if a tag is malformed.
+//
+// Example:
+//
+//	type User struct {
+//	    Name  string `json:"name" validate:"required,min=1,max=64"`
+//	    Email string `json:"email" validate:"required,email"`
+//	    Role  string `json:"role" validate:"oneof=admin user"`
+//	}
+func Validate(payload any) error {
+	value := reflect.ValueOf(payload)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	errs := make(Errors, 0)
+	if err := walk(value, "", &errs); err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func walk(value reflect.Value, path string, errs *Errors) error {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		return walkStruct(value, path, errs)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := walk(value.Index(i), fmt.Sprintf("%s[%d]", path, i), errs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func walkStruct(value reflect.Value, path string, errs *Errors) error {
+	t := value.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous {
+			if err := walk(value.Field(i), path, errs); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name := fieldPath(path, FieldName(field))
+
+		if err := check(value.Field(i), name, Rules(field.Tag.Get(TAG)), errs); err != nil {
+			return err
+		}
+
+		if err := walk(value.Field(i), name, errs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FieldName returns the name a field is serialized with, looking at the
+// json, xml, form and query tags in that order and falling back to the
+// field name.
+func FieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "xml", "form", "query"} {
+		name := strings.Split(field.Tag.Get(key), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func check(value reflect.Value, field string, rules []Rule, errs *Errors) error {
+	for _, rule := range rules {
+		switch rule.Name {
+		case OMITEMPTY:
+			if isEmpty(value) {
+				return nil
+			}
+			continue
+		case REQUIRED:
+			if isEmpty(value) {
+				*errs = append(*errs, fieldError(field, rule, "is required"))
+				return nil
+			}
+			continue
+		}
+
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return nil
+			}
+			value = value.Elem()
+		}
+
+		message, err := apply(value, rule)
+		if err != nil {
+			return fmt.Errorf("field '%s': %w", field, err)
+		}
+		if message != "" {
+			*errs = append(*errs, fieldError(field, rule, message))
+		}
+	}
+
+	return nil
+}
+
+func apply(value reflect.Value, rule Rule) (string, error) {
+	switch rule.Name {
+	case MIN, MAX, LEN:
+		return applySize(value, rule)
+	case EMAIL:
+		raw := stringify(value)
+		if address, err := mail.ParseAddress(raw); err != nil || address.Address != raw {
+			return "must be a valid e-mail address", nil
+		}
+	case ONEOF:
+		raw := stringify(value)
+		options := strings.Fields(rule.Param)
+		for _, v := range options {
+			if v == raw {
+				return "", nil
+			}
+		}
+		return fmt.Sprintf("must be one of [%s]", strings.Join(options, ", ")), nil
+	case PATTERN:
+		expression, err := pattern(rule.Param)
+		if err != nil {
+			return "", err
+		}
+		if !expression.MatchString(stringify(value)) {
+			return fmt.Sprintf("must match the pattern '%s'", rule.Param), nil
+		}
+	default:
+		return "", fmt.Errorf("unknown validation rule '%s'", rule.Name)
+	}
+
+	return "", nil
+}
+
+func applySize(value reflect.Value, rule Rule) (string, error) {
+	limit, err := strconv.ParseFloat(rule.Param, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid parameter '%s' for rule '%s'", rule.Param, rule.Name)
+	}
+
+	var size float64
+	var unit string
+
+	switch value.Kind() {
+	case reflect.String:
+		size, unit = float64(utf8.RuneCountInString(value.String())), " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		size, unit = float64(value.Len()), " items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		size = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		size = value.Float()
+	default:
+		return "", fmt.Errorf("rule '%s' is not supported for %s", rule.Name, value.Type())
+	}
+
+	switch {
+	case rule.Name == MIN && size < limit:
+		if unit == "" {
+			return fmt.Sprintf("must be at least %s", rule.Param), nil
+		}
+		return fmt.Sprintf("must contain at least %s%s", rule.Param, unit), nil
+	case rule.Name == MAX && size > limit:
+		if unit == "" {
+			return fmt.Sprintf("must be at most %s", rule.Param), nil
+		}
+		return fmt.Sprintf("must contain at most %s%s", rule.Param, unit), nil
+	case rule.Name == LEN && size != limit:
+		if unit == "" {
+			return fmt.Sprintf("must be exactly %s", rule.Param), nil
+		}
+		return fmt.Sprintf("must contain exactly %s%s", rule.Param, unit), nil
+	}
+
+	return "", nil
+}
+
+func pattern(raw string) (*regexp.Regexp, error) {
+	if cached, ok := patterns.Load(raw); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+
+	expression, err := regexp.Compile(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern '%s': %w", raw, err)
+	}
+
+	patterns.Store(raw, expression)
+	return expression, nil
+}
+
+func stringify(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	}
+	if value.CanInterface() {
+		return fmt.Sprint(value.Interface())
+	}
+	return ""
+}
+
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return value.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	}
+	return value.IsZero()
+}
+
+func fieldError(field string, rule Rule, message string) FieldError {
+	return FieldError{
+		Field:   field,
+		Rule:    rule.Name,
+		Param:   rule.Param,
+		Message: message,
+	}
+}
@@ -0,0 +1,331 @@
+package websocket
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// MessageType identifies the kind of a data message.
+type MessageType int
+
+const (
+	TextMessage   MessageType = 1
+	BinaryMessage MessageType = 2
+)
+
+// Close status codes defined by RFC 6455, section 7.4.1.
+const (
+	CloseNormal          = 1000
+	CloseGoingAway       = 1001
+	CloseProtocolError   = 1002
+	CloseUnsupportedData = 1003
+	CloseNoStatus        = 1005
+	CloseAbnormal        = 1006
+	CloseInvalidPayload  = 1007
+	ClosePolicyViolation = 1008
+	CloseMessageTooBig   = 1009
+	CloseInternalError   = 1011
+)
+
+const (
+	opContinuation byte = 0x0
+	opText         byte = 0x1
+	opBinary       byte = 0x2
+	opClose        byte = 0x8
+	opPing         byte = 0x9
+	opPong         byte = 0xA
+)
+
+const writeWait = 10 * time.Second
+
+// ErrClosed is returned when writing to a connection whose close frame
+// has already been sent.
+var ErrClosed = errors.New("websocket: connection closed")
+
+// CloseError is returned by ReadMessage when the peer closes the
+// connection, or when the connection is closed because of a protocol
+// violation.
+type CloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *CloseError) Error() string {
+	return fmt.Sprintf("websocket: close %d %s", e.Code, e.Reason)
+}
+
+// Conn is a server-side WebSocket connection.
+//
+// A Conn supports one concurrent reader and any number of concurrent
+// writers. Ping frames are answered automatically while reading.
+type Conn struct {
+	conn        net.Conn
+	reader      *bufio.Reader
+	subprotocol string
+	compress    bool
+	maxMessage  int64
+	ping        time.Duration
+
+	writeMu   sync.Mutex
+	closeSent bool
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newConn(conn net.Conn, reader *bufio.Reader, subprotocol string, compress bool, opts Options) *Conn {
+	maxMessage := opts.MaxMessageSize
+	if maxMessage <= 0 || maxMessage > MAX_MESSAGE_SIZE {
+		maxMessage = MAX_MESSAGE_SIZE
+	}
+
+	c := &Conn{
+		conn:        conn,
+		reader:      reader,
+		subprotocol: subprotocol,
+		compress:    compress,
+		maxMessage:  maxMessage,
+		ping:        opts.PingInterval,
+		closed:      make(chan struct{}),
+	}
+
+	if c.ping > 0 {
+		go c.keepAlive()
+	}
+
+	return c
+}
+
+// Subprotocol returns the negotiated subprotocol, or an empty string.
+func (c *Conn) Subprotocol() string {
+	return c.subprotocol
+}
+
+// Compressed reports whether permessage-deflate was negotiated.
+func (c *Conn) Compressed() bool {
+	return c.compress
+}
+
+// RemoteAddr returns the remote network address.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// ReadMessage reads the next data message, reassembling fragments and
+// handling control frames.
+//
+// When the peer sends a close frame, it is echoed and a *CloseError with
+// the peer's code is returned. Protocol violations close the connection
+// with the matching status code and are also reported as *CloseError.
+func (c *Conn) ReadMessage() (MessageType, []byte, error) {
+	var message bytes.Buffer
+	var kind byte
+	var compressed bool
+
+	for {
+		frame, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if frame.opcode >= opClose {
+			if err := c.control(frame); err != nil {
+				return 0, nil, err
+			}
+			continue
+		}
+
+		switch {
+		case frame.opcode == opContinuation && kind == 0:
+			return 0, nil, c.fail(CloseProtocolError, "unexpected continuation frame")
+		case frame.opcode != opContinuation && kind != 0:
+			return 0, nil, c.fail(CloseProtocolError, "expected continuation frame")
+		case frame.opcode != opContinuation && frame.opcode != opText && frame.opcode != opBinary:
+			return 0, nil, c.fail(CloseProtocolError, "unknown opcode")
+		case frame.opcode == opContinuation && frame.rsv1:
+			return 0, nil, c.fail(CloseProtocolError, "unexpected RSV1 bit")
+		}
+
+		if frame.opcode != opContinuation {
+			kind = frame.opcode
+			compressed = frame.rsv1
+		}
+
+		if int64(message.Len()+len(frame.payload)) > c.maxMessage {
+			return 0, nil, c.fail(CloseMessageTooBig, "message too big")
+		}
+		message.Write(frame.payload)
+
+		if !frame.fin {
+			continue
+		}
+
+		payload := message.Bytes()
+		if compressed {
+			if payload, err = decompress(payload, c.maxMessage); err != nil {
+				if errors.Is(err, errTooBig) {
+					return 0, nil, c.fail(CloseMessageTooBig, "message too big")
+				}
+				return 0, nil, c.fail(CloseInvalidPayload, "invalid compressed payload")
+			}
+		}
+
+		if kind == opText && !utf8.Valid(payload) {
+			return 0, nil, c.fail(CloseInvalidPayload, "invalid UTF-8 text")
+		}
+
+		return MessageType(kind), payload, nil
+	}
+}
+
+// ReadJSON reads the next data message and decodes it as JSON into v.
+func (c *Conn) ReadJSON(v any) error {
+	_, data, err := c.ReadMessage()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// WriteMessage sends a data message in a single frame, compressed when
+// permessage-deflate was negotiated.
+func (c *Conn) WriteMessage(kind MessageType, data []byte) error {
+	if kind != TextMessage && kind != BinaryMessage {
+		return fmt.Errorf("websocket: invalid message type %d", kind)
+	}
+
+	if c.compress {
+		compressed, err := compress(data)
+		if err != nil {
+			return err
+		}
+		return c.writeFrame(byte(kind), true, compressed)
+	}
+
+	return c.writeFrame(byte(kind), false, data)
+}
+
+// WriteText sends a text message.
+func (c *Conn) WriteText(text string) error {
+	return c.WriteMessage(TextMessage, []byte(text))
+}
+
+// WriteJSON encodes v as JSON and sends it as a text message.
+func (c *Conn) WriteJSON(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.WriteMessage(TextMessage, data)
+}
+
+// Ping sends a ping frame with an optional payload of up to 125 bytes.
+func (c *Conn) Ping(data []byte) error {
+	if len(data) > 125 {
+		return errors.New("websocket: control frame payload too big")
+	}
+	return c.writeFrame(opPing, false, data)
+}
+
+// Close sends a close frame with the given code and reason, and closes
+// the underlying connection.
+func (c *Conn) Close(code int, reason string) error {
+	err := c.writeClose(code, reason)
+	c.release()
+	if errors.Is(err, ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+func (c *Conn) control(frame frame) error {
+	switch frame.opcode {
+	case opPing:
+		if err := c.writeFrame(opPong, false, frame.payload); err != nil && !errors.Is(err, ErrClosed) {
+			return err
+		}
+		return nil
+	case opPong:
+		return nil
+	case opClose:
+		code, reason := CloseNoStatus, ""
+		switch {
+		case len(frame.payload) == 1:
+			return c.fail(CloseProtocolError, "invalid close payload")
+		case len(frame.payload) >= 2:
+			code = int(binary.BigEndian.Uint16(frame.payload))
+			reason = string(frame.payload[2:])
+			if !validCloseCode(code) || !utf8.ValidString(reason) {
+				return c.fail(CloseProtocolError, "invalid close payload")
+			}
+		}
+
+		echo := code
+		if echo == CloseNoStatus {
+			echo = CloseNormal
+		}
+		_ = c.writeClose(echo, "")
+		c.release()
+
+		return &CloseError{Code: code, Reason: reason}
+	}
+
+	return c.fail(CloseProtocolError, "unknown control opcode")
+}
+
+func (c *Conn) fail(code int, reason string) error {
+	_ = c.writeClose(code, reason)
+	c.release()
+	return &CloseError{Code: code, Reason: reason}
+}
+
+func (c *Conn) writeClose(code int, reason string) error {
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	payload = append(payload, reason...)
+	if len(payload) > 125 {
+		payload = payload[:125]
+	}
+	return c.writeFrame(opClose, false, payload)
+}
+
+func (c *Conn) release() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.conn.Close()
+	})
+}
+
+func (c *Conn) keepAlive() {
+	ticker := time.NewTicker(c.ping)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.closed:
+			return
+		case <-ticker.C:
+			if err := c.Ping(nil); err != nil {
+				c.release()
+				return
+			}
+		}
+	}
+}
+
+func validCloseCode(code int) bool {
+	switch {
+	case code >= 3000 && code <= 4999:
+		return true
+	case code >= 1000 && code <= 1011:
+		return code != 1004 && code != CloseNoStatus && code != CloseAbnormal
+	}
+	return false
+}
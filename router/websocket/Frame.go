@@ -0,0 +1,170 @@
+package websocket
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+var errTooBig = errors.New("websocket: message too big")
+
+// deflateTail is appended to compressed messages before inflating them:
+// the sync flush marker removed by the sender, followed by an empty final
+// block so the reader ends cleanly.
+var deflateTail = []byte{0x00, 0x00, 0xff, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff}
+
+type frame struct {
+	fin     bool
+	rsv1    bool
+	opcode  byte
+	payload []byte
+}
+
+func (c *Conn) readFrame() (frame, error) {
+	if c.ping > 0 {
+		_ = c.conn.SetReadDeadline(time.Now().Add(2 * c.ping))
+	}
+
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		c.release()
+		return frame{}, err
+	}
+
+	f := frame{
+		fin:    header[0]&0x80 != 0,
+		rsv1:   header[0]&0x40 != 0,
+		opcode: header[0] & 0x0f,
+	}
+
+	if header[0]&0x30 != 0 || (f.rsv1 && !c.compress) {
+		return frame{}, c.fail(CloseProtocolError, "unexpected reserved bits")
+	}
+
+	if header[1]&0x80 == 0 {
+		return frame{}, c.fail(CloseProtocolError, "client frames must be masked")
+	}
+
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var extended [2]byte
+		if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+			c.release()
+			return frame{}, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+			c.release()
+			return frame{}, err
+		}
+		length = binary.BigEndian.Uint64(extended[:])
+		if length>>63 != 0 {
+			return frame{}, c.fail(CloseProtocolError, "invalid payload length")
+		}
+	}
+
+	if f.opcode >= opClose {
+		if !f.fin || length > 125 || f.rsv1 {
+			return frame{}, c.fail(CloseProtocolError, "invalid control frame")
+		}
+	}
+
+	if length > uint64(c.maxMessage) {
+		return frame{}, c.fail(CloseMessageTooBig, "message too big")
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+		c.release()
+		return frame{}, err
+	}
+
+	// The declared length is not trusted for the allocation: the buffer
+	// only grows as the payload actually arrives.
+	var payload bytes.Buffer
+	if _, err := io.CopyN(&payload, c.reader, int64(length)); err != nil {
+		c.release()
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return frame{}, err
+	}
+
+	f.payload = payload.Bytes()
+
+	for i := range f.payload {
+		f.payload[i] ^= mask[i%4]
+	}
+
+	return f, nil
+}
+
+func (c *Conn) writeFrame(opcode byte, rsv1 bool, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.closeSent {
+		return ErrClosed
+	}
+	if opcode == opClose {
+		c.closeSent = true
+	}
+
+	header := make([]byte, 2, 10+len(payload))
+	header[0] = 0x80 | opcode
+	if rsv1 {
+		header[0] |= 0x40
+	}
+
+	switch length := len(payload); {
+	case length <= 125:
+		header[1] = byte(length)
+	case length <= 0xffff:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header, uint16(length))
+	default:
+		header[1] = 127
+		header = binary.BigEndian.AppendUint64(header, uint64(length))
+	}
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	_, err := c.conn.Write(append(header, payload...))
+	return err
+}
+
+func compress(data []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	writer, err := flate.NewWriter(&buffer, flate.DefaultCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Flush(); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buffer.Bytes(), deflateTail[:4]), nil
+}
+
+func decompress(data []byte, limit int64) ([]byte, error) {
+	reader := flate.NewReader(io.MultiReader(bytes.NewReader(data), bytes.NewReader(deflateTail)))
+	defer reader.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(raw)) > limit {
+		return nil, errTooBig
+	}
+	return raw, nil
+}
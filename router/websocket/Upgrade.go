@@ -0,0 +1,219 @@
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GUID is the value concatenated to the client key to compute
+// Sec-WebSocket-Accept, as defined by RFC 6455.
+const GUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// VERSION is the only protocol version supported.
+const VERSION = "13"
+
+// DEFAULT_MAX_MESSAGE_SIZE is the message size limit used by DefaultOptions.
+const DEFAULT_MAX_MESSAGE_SIZE = 1 << 20
+
+// MAX_MESSAGE_SIZE is the upper bound of a received message, applied when
+// Options.MaxMessageSize is unset or larger.
+const MAX_MESSAGE_SIZE = 1 << 30
+
+// Options configures the upgrade and the resulting connection.
+type Options struct {
+	MaxMessageSize int64                    // Maximum size of a received message in bytes (0 means MAX_MESSAGE_SIZE)
+	Compression    bool                     // Whether to negotiate permessage-deflate (RFC 7692)
+	Subprotocols   []string                 // Supported subprotocols, in order of preference
+	CheckOrigin    func(*http.Request) bool // Origin validation; nil only allows same-host origins
+	PingInterval   time.Duration            // Interval of server pings; peers silent for twice as long are dropped (0 disables)
+}
+
+// DefaultOptions returns the options used by Router.WebSocket: a 1 MiB
+// message limit, no compression and same-host origins only.
+func DefaultOptions() Options {
+	return Options{
+		MaxMessageSize: DEFAULT_MAX_MESSAGE_SIZE,
+		Compression:    false,
+		Subprotocols:   make([]string, 0),
+		CheckOrigin:    nil,
+		PingInterval:   0,
+	}
+}
+
+// HandshakeError reports an invalid opening handshake, along with the
+// HTTP status that should be answered to the client.
+type HandshakeError struct {
+	Status  int
+	Message string
+}
+
+func (e *HandshakeError) Error() string {
+	return fmt.Sprintf("websocket handshake: %s", e.Message)
+}
+
+// Upgrade validates the opening handshake, hijacks the connection and
+// answers 101 Switching Protocols.
+//
+// On failure it returns a *HandshakeError and the response is left
+// untouched, so the caller can answer with the suggested status.
+func Upgrade(w http.ResponseWriter, r *http.Request, opts Options) (*Conn, error) {
+	key, err := validate(r, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	subprotocol := selectSubprotocol(r, opts.Subprotocols)
+	compress := opts.Compression && acceptsDeflate(r)
+
+	netConn, buffer, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		return nil, &HandshakeError{
+			Status:  http.StatusInternalServerError,
+			Message: err.Error(),
+		}
+	}
+
+	response := []string{
+		"HTTP/1.1 101 Switching Protocols",
+		"Upgrade: websocket",
+		"Connection: Upgrade",
+		"Sec-WebSocket-Accept: " + AcceptKey(key),
+	}
+	if subprotocol != "" {
+		response = append(response, "Sec-WebSocket-Protocol: "+subprotocol)
+	}
+	if compress {
+		response = append(response, "Sec-WebSocket-Extensions: permessage-deflate; server_no_context_takeover; client_no_context_takeover")
+	}
+
+	_ = netConn.SetDeadline(time.Time{})
+	if _, err := netConn.Write([]byte(strings.Join(response, "\r\n") + "\r\n\r\n")); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	reader := buffer.Reader
+	if reader == nil {
+		reader = bufio.NewReader(netConn)
+	}
+
+	return newConn(netConn, reader, subprotocol, compress, opts), nil
+}
+
+// AcceptKey computes the Sec-WebSocket-Accept value for a client key.
+func AcceptKey(key string) string {
+	hash := sha1.Sum([]byte(key + GUID))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// IsUpgrade reports whether the request asks for a WebSocket upgrade.
+func IsUpgrade(r *http.Request) bool {
+	return hasToken(r.Header, "Connection", "upgrade") &&
+		hasToken(r.Header, "Upgrade", "websocket")
+}
+
+func validate(r *http.Request, opts Options) (string, error) {
+	if r.Method != http.MethodGet {
+		return "", &HandshakeError{Status: http.StatusMethodNotAllowed, Message: "method must be GET"}
+	}
+
+	if !IsUpgrade(r) {
+		return "", &HandshakeError{Status: http.StatusUpgradeRequired, Message: "missing websocket upgrade headers"}
+	}
+
+	if r.Header.Get("Sec-WebSocket-Version") != VERSION {
+		return "", &HandshakeError{Status: http.StatusUpgradeRequired, Message: "unsupported version, expected " + VERSION}
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		return "", &HandshakeError{Status: http.StatusBadRequest, Message: "invalid Sec-WebSocket-Key"}
+	}
+
+	checkOrigin := opts.CheckOrigin
+	if checkOrigin == nil {
+		checkOrigin = sameOrigin
+	}
+	if !checkOrigin(r) {
+		return "", &HandshakeError{Status: http.StatusForbidden, Message: "origin not allowed"}
+	}
+
+	return key, nil
+}
+
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(parsed.Host, r.Host)
+}
+
+func selectSubprotocol(r *http.Request, supported []string) string {
+	requested := tokens(r.Header, "Sec-WebSocket-Protocol")
+	for _, s := range supported {
+		for _, v := range requested {
+			if s == v {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// acceptsDeflate reports whether the client offers permessage-deflate with
+// parameters compatible with a stateless, full-window compressor.
+func acceptsDeflate(r *http.Request) bool {
+	for _, offer := range tokens(r.Header, "Sec-WebSocket-Extensions") {
+		params := strings.Split(offer, ";")
+		if strings.TrimSpace(params[0]) != "permessage-deflate" {
+			continue
+		}
+
+		compatible := true
+		for _, p := range params[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(p), "=")
+			if name == "server_max_window_bits" && strings.Trim(value, `"`) != "15" {
+				compatible = false
+			}
+		}
+
+		if compatible {
+			return true
+		}
+	}
+	return false
+}
+
+func tokens(header http.Header, key string) []string {
+	result := make([]string, 0)
+	for _, line := range header.Values(key) {
+		for _, v := range strings.Split(line, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				result = append(result, v)
+			}
+		}
+	}
+	return result
+}
+
+func hasToken(header http.Header, key, token string) bool {
+	for _, v := range tokens(header, key) {
+		if strings.EqualFold(v, token) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package router_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/log"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+func accessRequest(route http.Handler, remote string, headers map[string]string) {
+	r := httptest.NewRequest(http.MethodGet, "/users/7?full=true", nil)
+	r.RemoteAddr = remote
+	for k, v := range headers {
+		r.Header.Set(k, v)
+	}
+	route.ServeHTTP(httptest.NewRecorder(), r)
+}
+
+func accessRouter(access *router.AccessLog) (*router.Router, log.Log, *syncBuffer) {
+	buffer := &syncBuffer{}
+	logger := log.NewAsyncLogger(log.AsyncOpts{Writer: buffer})
+
+	route := routeOf(http.MethodGet, "/users/{id}", func(r *http.Request) result.Result {
+		return result.TextOk("hello")
+	}).
+		Logger(logger).
+		AccessLog(access)
+
+	return route, logger, buffer
+}
+
+func flushAccess(t *testing.T, logger log.Log, buffer *syncBuffer) []string {
+	t.Helper()
+	logger.(log.Flusher).Close()
+
+	lines := make([]string, 0)
+	for _, line := range buffer.Lines() {
+		if _, message, ok := strings.Cut(line, "[ACCESS]: "); ok {
+			lines = append(lines, message)
+		}
+	}
+	return lines
+}
+
+func TestAccessLog_Combined(t *testing.T) {
+	route, logger, buffer := accessRouter(router.NewAccessLog())
+
+	accessRequest(route, "192.0.2.10:4000", map[string]string{
+		"User-Agent": "curl/8.0",
+		"Referer":    "https://example.com",
+	})
+
+	lines := flushAccess(t, logger, buffer)
+	format := regexp.MustCompile(`^192\.0\.2\.10 - - \[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] "GET /users/7\?full=true HTTP/1\.1" 200 5 "https://example.com" "curl/8\.0"$`)
+	if len(lines) != 1 || !format.MatchString(lines[0]) {
+		t.Fatalf("unexpected access log %v", lines)
+	}
+}
+
+func TestAccessLog_Formats(t *testing.T) {
+	cases := []struct {
+		name   string
+		access *router.AccessLog
+		expect func(line string) bool
+	}{
+		{"common", router.NewAccessLog().Common(), func(line string) bool {
+			return strings.HasSuffix(line, `"GET /users/7?full=true HTTP/1.1" 200 5`)
+		}},
+		{"template", router.NewAccessLog().Template("{{.Method}} {{.Pattern}} {{.Status}}"), func(line string) bool {
+			return line == "GET GET /users/{id} 200"
+		}},
+		{"json", router.NewAccessLog().Json(), func(line string) bool {
+			var entry router.AccessEntry
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				return false
+			}
+			return entry.Status == 200 && entry.Bytes == 5 && entry.Path == "/users/7" && entry.Duration > 0
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			route, logger, buffer := accessRouter(c.access)
+			accessRequest(route, "192.0.2.10:4000", nil)
+
+			lines := flushAccess(t, logger, buffer)
+			if len(lines) != 1 || !c.expect(lines[0]) {
+				t.Fatalf("unexpected access log %v", lines)
+			}
+		})
+	}
+}
+
+func TestAccessLog_TrustedProxies(t *testing.T) {
+	access := router.NewAccessLog().
+		Template("{{.Remote}}").
+		TrustedProxies("10.0.0.0/8", "192.0.2.1")
+
+	cases := []struct {
+		name    string
+		remote  string
+		headers map[string]string
+		expect  string
+	}{
+		{"direct client", "203.0.113.5:1000", map[string]string{"X-Forwarded-For": "198.51.100.1"}, "203.0.113.5"},
+		{"trusted chain", "10.0.0.2:1000", map[string]string{"X-Forwarded-For": "198.51.100.1, 203.0.113.9, 192.0.2.1"}, "203.0.113.9"},
+		{"real ip", "192.0.2.1:1000", map[string]string{"X-Real-IP": "198.51.100.7"}, "198.51.100.7"},
+		{"hop with port", "10.0.0.2:1000", map[string]string{"X-Forwarded-For": "[2001:db8::1]:443"}, "2001:db8::1"},
+		{"forged hop", "10.0.0.2:1000", map[string]string{"X-Forwarded-For": `203.0.113.9, 1.2.3.4" 200 0 "x`}, "10.0.0.2"},
+		{"forged real ip", "192.0.2.1:1000", map[string]string{"X-Real-IP": `198.51.100.7 - admin`}, "192.0.2.1"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			route, logger, buffer := accessRouter(access)
+			accessRequest(route, c.remote, c.headers)
+
+			lines := flushAccess(t, logger, buffer)
+			if len(lines) != 1 || lines[0] != c.expect {
+				t.Fatalf("expected %q, got %v", c.expect, lines)
+			}
+		})
+	}
+}
+
+func TestAccessLog_EscapesClientFields(t *testing.T) {
+	route, logger, buffer := accessRouter(router.NewAccessLog())
+
+	r := httptest.NewRequest(http.MethodGet, "/users/%22x%20y%22", nil)
+	r.RemoteAddr = "192.0.2.10:4000"
+	r.Header.Set("User-Agent", `curl "injected" \ agent`)
+	route.ServeHTTP(httptest.NewRecorder(), r)
+
+	lines := flushAccess(t, logger, buffer)
+	expect := `"GET /users/%22x%20y%22 HTTP/1.1" 200 5 "-" "curl \x22injected\x22 \x5c agent"`
+	if len(lines) != 1 || !strings.HasSuffix(lines[0], expect) {
+		t.Fatalf("expected %q, got %v", expect, lines)
+	}
+}
+
+func TestAccessLog_Attributes(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := log.SlogLogger(slog.New(slog.NewJSONHandler(&buffer, nil)))
+
+	route := router.NewRouter().
+		AccessLog(router.NewAccessLog().Logger(logger)).
+		Route(http.MethodGet, textHandler("hello"), "/users/{id}")
+
+	accessRequest(route, "192.0.2.10:4000", map[string]string{"X-Request-Id": "req-9"})
+
+	records := decodeRecords(t, &buffer)
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %v", records)
+	}
+	record := records[0]
+	if record[log.CATEGORY] != router.ACCESS || record[log.STATUS] != float64(200) ||
+		record[log.ROUTE] != "GET /users/{id}" || record[log.REQUEST_ID] != "req-9" {
+		t.Fatalf("unexpected record %v", record)
+	}
+}
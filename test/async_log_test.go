@@ -0,0 +1,107 @@
+package router_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/log"
+)
+
+type syncBuffer struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+	gate   chan struct{}
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	if b.gate != nil {
+		<-b.gate
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) Lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return strings.Split(strings.TrimSpace(b.buffer.String()), "\n")
+}
+
+func TestAsyncLogger_Ordered(t *testing.T) {
+	buffer := &syncBuffer{}
+	logger := log.NewAsyncLogger(log.AsyncOpts{Writer: buffer, QueueSize: 4})
+
+	for i := range 100 {
+		logger.Messagef("line %d", i)
+	}
+	logger.(log.Flusher).Flush()
+
+	lines := buffer.Lines()
+	if len(lines) != 100 {
+		t.Fatalf("expected 100 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if !strings.HasSuffix(line, fmt.Sprintf("[MESSAGE]: line %d", i)) {
+			t.Fatalf("unexpected line %d: %q", i, line)
+		}
+	}
+
+	format := regexp.MustCompile(`^\(GO-WEB\) - \d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d{3} - \[MESSAGE\]: line 0$`)
+	if !format.MatchString(lines[0]) {
+		t.Fatalf("unexpected format %q", lines[0])
+	}
+}
+
+func TestAsyncLogger_DropPolicy(t *testing.T) {
+	buffer := &syncBuffer{gate: make(chan struct{})}
+	logger := log.NewAsyncLogger(log.AsyncOpts{Writer: buffer, QueueSize: 2, Policy: log.DROP})
+
+	for i := range 10 {
+		logger.Messagef("line %d", i)
+	}
+	close(buffer.gate)
+	logger.(log.Flusher).Close()
+
+	lines := buffer.Lines()
+	if len(lines) >= 10 {
+		t.Fatalf("expected records to be dropped, got %d lines", len(lines))
+	}
+	if !slices.ContainsFunc(lines, func(line string) bool {
+		return strings.Contains(line, "log records dropped")
+	}) {
+		t.Fatalf("expected a dropped records warning, got %v", lines)
+	}
+}
+
+func TestAsyncLogger_CloseOnShutdown(t *testing.T) {
+	buffer := &syncBuffer{}
+	logger := log.NewAsyncLogger(log.AsyncOpts{Writer: buffer})
+
+	route := router.NewRouter().Logger(logger)
+	logger.Message("before shutdown")
+
+	if err := route.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger.Message("after shutdown")
+	logger.(log.Flusher).Close()
+
+	lines := buffer.Lines()
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], "before shutdown") || !strings.HasSuffix(lines[1], "after shutdown") {
+		t.Fatalf("expected a logger set with Logger to outlive the Router, got %v", lines)
+	}
+
+	logger.Message("after close")
+	if lines := buffer.Lines(); len(lines) != 3 || !strings.HasSuffix(lines[2], "after close") {
+		t.Fatalf("expected records after close to be written synchronously, got %v", lines)
+	}
+}
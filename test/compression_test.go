@@ -0,0 +1,181 @@
+package router_test
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+func compressionRouter(body string) *router.Router {
+	return routeOf(http.MethodGet, "/text", func(r *http.Request) result.Result {
+		return result.Ok(body)
+	}).
+		Compression(router.DefaultCompression().MinSize(64)).
+		Route(http.MethodGet, func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			return result.Ok(body).WithContentType("image/png")
+		}, "/image")
+}
+
+func TestCompression_Gzip(t *testing.T) {
+	body := strings.Repeat("compressible ", 20)
+	route := compressionRouter(body)
+
+	w := doRequest(t, route, http.MethodGet, "/text", map[string]string{"Accept-Encoding": "deflate;q=0.5, gzip"})
+
+	if encoding := w.Header().Get("Content-Encoding"); encoding != "gzip" {
+		t.Fatalf("expected gzip encoding, got %q", encoding)
+	}
+	if vary := w.Header().Get("Vary"); vary != "Accept-Encoding" {
+		t.Fatalf("unexpected Vary %q", vary)
+	}
+	if length := w.Header().Get("Content-Length"); length != "" {
+		t.Fatalf("unexpected Content-Length %q", length)
+	}
+
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, _ := io.ReadAll(reader)
+	if string(decoded) != body {
+		t.Fatalf("unexpected body %q", decoded)
+	}
+}
+
+func TestCompression_Deflate(t *testing.T) {
+	body := strings.Repeat("compressible ", 20)
+	route := compressionRouter(body)
+
+	w := doRequest(t, route, http.MethodGet, "/text", map[string]string{"Accept-Encoding": "gzip;q=0, deflate"})
+
+	if encoding := w.Header().Get("Content-Encoding"); encoding != "deflate" {
+		t.Fatalf("expected deflate encoding, got %q", encoding)
+	}
+
+	reader, err := zlib.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, _ := io.ReadAll(reader)
+	if string(decoded) != body {
+		t.Fatalf("unexpected body %q", decoded)
+	}
+}
+
+func TestCompression_Skipped(t *testing.T) {
+	body := strings.Repeat("compressible ", 20)
+	route := compressionRouter(body)
+
+	cases := []struct {
+		name    string
+		path    string
+		headers map[string]string
+	}{
+		{"no accept encoding", "/text", map[string]string{}},
+		{"unsupported encoding", "/text", map[string]string{"Accept-Encoding": "br"}},
+		{"content type not allowed", "/image", map[string]string{"Accept-Encoding": "gzip"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := doRequest(t, route, http.MethodGet, c.path, c.headers)
+			if encoding := w.Header().Get("Content-Encoding"); encoding != "" {
+				t.Fatalf("unexpected encoding %q", encoding)
+			}
+			if w.Body.String() != body {
+				t.Fatalf("unexpected body %q", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompression_BelowMinSize(t *testing.T) {
+	route := compressionRouter("short")
+
+	w := doRequest(t, route, http.MethodGet, "/text", map[string]string{"Accept-Encoding": "gzip"})
+
+	if encoding := w.Header().Get("Content-Encoding"); encoding != "" {
+		t.Fatalf("unexpected encoding %q", encoding)
+	}
+	if vary := w.Header().Get("Vary"); vary != "Accept-Encoding" {
+		t.Fatalf("unexpected Vary %q", vary)
+	}
+	if w.Body.String() != "short" {
+		t.Fatalf("unexpected body %q", w.Body.String())
+	}
+}
+
+func TestCompression_Stream(t *testing.T) {
+	route := router.NewRouter().
+		Compression(router.DefaultCompression()).
+		Route(http.MethodGet, func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			return result.Stream(strings.NewReader("small stream")).WithContentType("text/plain")
+		}, "/stream")
+
+	w := doRequest(t, route, http.MethodGet, "/stream", map[string]string{"Accept-Encoding": "gzip"})
+
+	if encoding := w.Header().Get("Content-Encoding"); encoding != "gzip" {
+		t.Fatalf("expected a flushed stream to be compressed, got %q", encoding)
+	}
+	if !w.Flushed {
+		t.Fatal("expected the stream to be flushed")
+	}
+
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, _ := io.ReadAll(reader)
+	if string(decoded) != "small stream" {
+		t.Fatalf("unexpected body %q", decoded)
+	}
+}
+
+func TestCompression_Resources(t *testing.T) {
+	t.Chdir(t.TempDir())
+	content := strings.Repeat("body { margin: 0; }\n", 100)
+	if err := os.Mkdir("static", 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("static", "site.css"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	route := router.NewRouter().
+		Compression(router.DefaultCompression()).
+		ResourcesPath("static")
+
+	w := doRequest(t, route, http.MethodGet, "/static/site.css", map[string]string{"Accept-Encoding": "gzip"})
+
+	if encoding := w.Header().Get("Content-Encoding"); encoding != "gzip" {
+		t.Fatalf("expected gzip encoding, got %q", encoding)
+	}
+	if length := w.Header().Get("Content-Length"); length != "" {
+		t.Fatalf("unexpected Content-Length %q", length)
+	}
+
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, _ := io.ReadAll(reader)
+	if string(decoded) != content {
+		t.Fatal("unexpected decompressed resource")
+	}
+
+	ranged := doRequest(t, route, http.MethodGet, "/static/site.css", map[string]string{
+		"Accept-Encoding": "gzip",
+		"Range":           "bytes=0-9",
+	})
+	if ranged.Code != http.StatusPartialContent || ranged.Header().Get("Content-Encoding") != "" {
+		t.Fatalf("expected an uncompressed partial response, got %d %q", ranged.Code, ranged.Header().Get("Content-Encoding"))
+	}
+}
@@ -0,0 +1,166 @@
+package router_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+var conditionalModified = time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+func conditionalRouter() *router.Router {
+	return routeOf(http.MethodGet, "/auto", func(r *http.Request) result.Result {
+		return result.JsonOk(testUser{Name: "Ada", Age: 36}).WithAutoETag()
+	}).
+		Route(http.MethodGet, func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			return result.JsonOk(testUser{Name: "Ada", Age: 36}).
+				WithETag("v2").
+				WithLastModified(conditionalModified).
+				WithHeader("Cache-Control", "max-age=60")
+		}, "/explicit").
+		Route(http.MethodPut, func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			if res := router.Preconditions(r, "v2", conditionalModified); res != nil {
+				return *res
+			}
+			return result.Accept(http.StatusNoContent)
+		}, "/explicit").
+		Route(http.MethodPatch, func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			if res := router.Preconditions(r, "v1", time.Time{}); res != nil {
+				return *res
+			}
+			return result.JsonOk(testUser{Name: "Ada", Age: 37}).WithETag("v2")
+		}, "/explicit")
+}
+
+func TestConditional_AutoETag(t *testing.T) {
+	route := conditionalRouter()
+
+	first := doRequest(t, route, http.MethodGet, "/auto")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("expected a hashed ETag, got %d %q", first.Code, etag)
+	}
+
+	second := doRequest(t, route, http.MethodGet, "/auto", map[string]string{"If-None-Match": `"other", ` + etag})
+	if second.Code != http.StatusNotModified || second.Body.Len() != 0 {
+		t.Fatalf("expected an empty 304, got %d %q", second.Code, second.Body.String())
+	}
+	if second.Header().Get("ETag") != etag {
+		t.Fatalf("expected the ETag on the 304, got %q", second.Header().Get("ETag"))
+	}
+}
+
+func TestConditional_Get(t *testing.T) {
+	route := conditionalRouter()
+
+	cases := []struct {
+		name    string
+		headers map[string]string
+		status  int
+	}{
+		{"unconditional", map[string]string{}, http.StatusOK},
+		{"matching etag", map[string]string{"If-None-Match": `"v2"`}, http.StatusNotModified},
+		{"weak match", map[string]string{"If-None-Match": `W/"v2"`}, http.StatusNotModified},
+		{"wildcard", map[string]string{"If-None-Match": "*"}, http.StatusNotModified},
+		{"stale etag", map[string]string{"If-None-Match": `"v1"`}, http.StatusOK},
+		{"not modified since", map[string]string{"If-Modified-Since": conditionalModified.Format(http.TimeFormat)}, http.StatusNotModified},
+		{"modified since", map[string]string{"If-Modified-Since": conditionalModified.Add(-time.Hour).Format(http.TimeFormat)}, http.StatusOK},
+		{"etag over date", map[string]string{
+			"If-None-Match":     `"v1"`,
+			"If-Modified-Since": conditionalModified.Format(http.TimeFormat),
+		}, http.StatusOK},
+		{"failed if-match", map[string]string{"If-Match": `"v1"`}, http.StatusPreconditionFailed},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := doRequest(t, route, http.MethodGet, "/explicit", c.headers)
+			if w.Code != c.status {
+				t.Fatalf("expected %d, got %d", c.status, w.Code)
+			}
+			if c.status == http.StatusNotModified && w.Header().Get("Cache-Control") != "max-age=60" {
+				t.Fatal("expected the result headers on the 304")
+			}
+		})
+	}
+}
+
+func TestConditional_FailedUsesErrorHandler(t *testing.T) {
+	route := conditionalRouter().
+		ErrorHandler(func(w http.ResponseWriter, r *http.Request, ctx *router.Context, res result.Result) {
+			w.WriteHeader(res.Status())
+			w.Write([]byte("handled"))
+		})
+
+	w := doRequest(t, route, http.MethodGet, "/explicit", map[string]string{"If-Match": `"v1"`})
+	if w.Code != http.StatusPreconditionFailed || w.Body.String() != "handled" {
+		t.Fatalf("expected the error handler to answer the 412, got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestConditional_Preconditions(t *testing.T) {
+	route := conditionalRouter()
+
+	cases := []struct {
+		name    string
+		headers map[string]string
+		status  int
+	}{
+		{"matching if-match", map[string]string{"If-Match": `"v2"`}, http.StatusNoContent},
+		{"weak if-match", map[string]string{"If-Match": `W/"v2"`}, http.StatusPreconditionFailed},
+		{"stale if-match", map[string]string{"If-Match": `"v1"`}, http.StatusPreconditionFailed},
+		{"unmodified since", map[string]string{"If-Unmodified-Since": conditionalModified.Format(http.TimeFormat)}, http.StatusNoContent},
+		{"modified since", map[string]string{"If-Unmodified-Since": conditionalModified.Add(-time.Hour).Format(http.TimeFormat)}, http.StatusPreconditionFailed},
+		{"if-none-match on update", map[string]string{"If-None-Match": "*"}, http.StatusPreconditionFailed},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := doRequest(t, route, http.MethodPut, "/explicit", c.headers)
+			if w.Code != c.status {
+				t.Fatalf("expected %d, got %d", c.status, w.Code)
+			}
+			if c.status == http.StatusPreconditionFailed && w.Header().Get("Content-Type") != "application/problem+json" {
+				t.Fatalf("expected a problem, got %q", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestConditional_UpdateReturnsNewETag(t *testing.T) {
+	route := conditionalRouter()
+
+	w := doRequest(t, route, http.MethodPatch, "/explicit", map[string]string{"If-Match": `"v1"`})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the update to succeed, got %d %q", w.Code, w.Body.String())
+	}
+	if w.Header().Get("ETag") != `"v2"` {
+		t.Fatalf("expected the new ETag, got %q", w.Header().Get("ETag"))
+	}
+
+	stale := doRequest(t, route, http.MethodPatch, "/explicit", map[string]string{"If-Match": `"v0"`})
+	if stale.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected a stale update to fail, got %d", stale.Code)
+	}
+}
+
+func TestConditional_HashETag(t *testing.T) {
+	res := result.JsonOk(nil).WithAutoETag().ResolveETag([]byte("content"))
+	if res.ETag() != result.HashETag([]byte("content")) {
+		t.Fatalf("unexpected ETag %q", res.ETag())
+	}
+
+	explicit := result.JsonOk(nil).WithWeakETag("v1").WithAutoETag().ResolveETag([]byte("content"))
+	if explicit.ETag() != `W/"v1"` {
+		t.Fatalf("expected the explicit ETag to take precedence, got %q", explicit.ETag())
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if res := router.Preconditions(r, "v1", time.Time{}); res != nil {
+		t.Fatalf("expected an unconditional request to proceed, got %d", res.Status())
+	}
+}
@@ -0,0 +1,115 @@
+package router_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+func newEncodedRequest(encoding string, body []byte) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Content-Encoding", encoding)
+	return r
+}
+
+func compress(t *testing.T, encoding string, data []byte) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	var writer io.WriteCloser
+	switch encoding {
+	case "gzip":
+		writer = gzip.NewWriter(&buffer)
+	case "zlib":
+		writer = zlib.NewWriter(&buffer)
+	case "flate":
+		writer, _ = flate.NewWriter(&buffer, flate.DefaultCompression)
+	}
+
+	writer.Write(data)
+	writer.Close()
+	return buffer.Bytes()
+}
+
+func TestInput_DecompressBody(t *testing.T) {
+	body := []byte(`{"name":"Ada","email":"ada@mail.com"}`)
+
+	cases := []struct {
+		name     string
+		encoding string
+		raw      []byte
+	}{
+		{"gzip", "gzip", compress(t, "gzip", body)},
+		{"deflate zlib", "deflate", compress(t, "zlib", body)},
+		{"deflate raw", "deflate", compress(t, "flate", body)},
+		{"stacked", "deflate, gzip", compress(t, "gzip", compress(t, "zlib", body))},
+		{"identity", "identity", body},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			contact, res := router.Input[testContact](newEncodedRequest(c.encoding, c.raw))
+			if res != nil {
+				t.Fatalf("unexpected result %d: %v", res.Status(), res.Payload())
+			}
+			if contact.Name != "Ada" || contact.Email != "ada@mail.com" {
+				t.Fatalf("unexpected payload %+v", contact)
+			}
+		})
+	}
+}
+
+func TestInput_DecompressClosesReaders(t *testing.T) {
+	r := newEncodedRequest("gzip", compress(t, "gzip", []byte("content")))
+
+	data, res := router.InputBytes(r)
+	if res != nil || string(data) != "content" {
+		t.Fatalf("unexpected body %q: %v", data, res)
+	}
+
+	if _, err := r.Body.Read(make([]byte, 1)); !errors.Is(err, http.ErrBodyReadAfterClose) {
+		t.Fatalf("expected the decompressed body to be closed, got %v", err)
+	}
+}
+
+func TestInput_DecompressLimit(t *testing.T) {
+	body := []byte(`{"name":"` + strings.Repeat("a", 64<<10) + `"}`)
+	raw := compress(t, "gzip", body)
+
+	opts := router.InputOpts{Limit: 1 << 10, Strict: true}
+	_, res := router.InputWithOpts[testContact](httptest.NewRecorder(), newEncodedRequest("gzip", raw), opts)
+	if res == nil || res.Status() != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 for a body over the decompressed limit, got %v", res)
+	}
+
+	data, res := router.InputBytesWithOpts(nil, newEncodedRequest("gzip", raw), router.InputOpts{Limit: 1 << 10})
+	if res != nil || len(data) != 1<<10 {
+		t.Fatalf("expected a lax read truncated to the limit, got %d bytes", len(data))
+	}
+}
+
+func TestInput_DecompressErrors(t *testing.T) {
+	_, res := router.Input[testContact](newEncodedRequest("br", []byte("{}")))
+	if res == nil || res.Status() != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected 415 for an unknown encoding, got %v", res)
+	}
+	if _, ok := res.Payload().(result.Problem); !ok {
+		t.Fatalf("expected a problem payload, got %T", res.Payload())
+	}
+
+	_, res = router.Input[testContact](newEncodedRequest("gzip", []byte("not gzip")))
+	if res == nil || res.Status() != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a malformed body, got %v", res)
+	}
+}
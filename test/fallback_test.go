@@ -0,0 +1,74 @@
+package router_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+func TestRouter_DefaultNotFound(t *testing.T) {
+	route := router.NewRouter().
+		Route(http.MethodGet, textHandler("hello"), "/hello")
+
+	w := doRequest(t, route, http.MethodGet, "/missing")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", w.Code)
+	}
+}
+
+func TestRouter_CustomNotFoundUsesErrorHandler(t *testing.T) {
+	handled := false
+
+	route := router.NewRouter().
+		ErrorHandler(func(w http.ResponseWriter, r *http.Request, ctx *router.Context, res result.Result) {
+			handled = true
+			w.WriteHeader(res.Status())
+		}).
+		NotFound(func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			return result.JsonErr(http.StatusNotFound, map[string]string{"path": r.URL.Path})
+		}).
+		Route(http.MethodGet, textHandler("hello"), "/hello")
+
+	w := doRequest(t, route, http.MethodGet, "/missing")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", w.Code)
+	}
+
+	if handled {
+		t.Fatal("expected JsonErr result to be resolved without the error handler")
+	}
+
+	route.NotFound(func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+		return result.Reject(http.StatusNotFound)
+	})
+
+	doRequest(t, route, http.MethodGet, "/missing")
+	if !handled {
+		t.Fatal("expected error handler to be executed")
+	}
+}
+
+func TestRouter_MethodNotAllowed(t *testing.T) {
+	route := router.NewRouter().
+		Route(http.MethodGet, textHandler("get"), "/items/{%s}", "id").
+		Route(http.MethodPut, textHandler("put"), "/items/{%s}", "id").
+		Route(http.MethodPost, textHandler("post"), "/other").
+		MethodNotAllowed(func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			return result.TextErr(http.StatusMethodNotAllowed, "custom")
+		})
+
+	w := doRequest(t, route, http.MethodDelete, "/items/1")
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected status 405, got %d", w.Code)
+	}
+
+	if allow := w.Header().Get("Allow"); allow != "GET, HEAD, PUT" {
+		t.Fatalf("unexpected Allow header %q", allow)
+	}
+
+	if body := w.Body.String(); body != "custom\n" {
+		t.Fatalf("unexpected body %q", body)
+	}
+}
@@ -0,0 +1,147 @@
+package router_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/docs"
+	"github.com/Rafael24595/go-web/router/docs/swagger"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+var testFiles = fstest.MapFS{
+	"docs/readme.txt": {Data: []byte("0123456789abcdef"), ModTime: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)},
+	"docs/report.bin": {Data: []byte{0x00, 0x01, 0x02}},
+}
+
+func TestFile_FS(t *testing.T) {
+	route := routeOf(http.MethodGet, "/files/{name...}", func(r *http.Request) result.Result {
+		return result.FileFS(testFiles, "docs/"+r.PathValue("name"))
+	})
+
+	w := doRequest(t, route, http.MethodGet, "/files/readme.txt")
+	if w.Code != http.StatusOK || w.Body.String() != "0123456789abcdef" {
+		t.Fatalf("unexpected response: %d %q", w.Code, w.Body.String())
+	}
+	if content := w.Header().Get("Content-Type"); !strings.HasPrefix(content, "text/plain") {
+		t.Fatalf("unexpected content type %q", content)
+	}
+	if w.Header().Get("Last-Modified") == "" {
+		t.Fatal("expected Last-Modified header")
+	}
+
+	missing := doRequest(t, route, http.MethodGet, "/files/missing.txt")
+	if missing.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", missing.Code)
+	}
+}
+
+func TestFile_Range(t *testing.T) {
+	route := routeOf(http.MethodGet, "/files/{name...}", func(r *http.Request) result.Result {
+		return result.FileBytes("data.txt", []byte("0123456789abcdef"))
+	})
+
+	w := doRequest(t, route, http.MethodGet, "/files/data", map[string]string{"Range": "bytes=4-7"})
+	if w.Code != http.StatusPartialContent || w.Body.String() != "4567" {
+		t.Fatalf("unexpected response: %d %q", w.Code, w.Body.String())
+	}
+	if content := w.Header().Get("Content-Range"); content != "bytes 4-7/16" {
+		t.Fatalf("unexpected Content-Range %q", content)
+	}
+}
+
+func TestFile_MissingUsesErrorHandler(t *testing.T) {
+	route := routeOf(http.MethodGet, "/files/{name...}", func(r *http.Request) result.Result {
+		return result.FileFS(testFiles, "docs/missing.txt")
+	}).
+		ErrorHandler(func(w http.ResponseWriter, r *http.Request, ctx *router.Context, res result.Result) {
+			w.WriteHeader(res.Status())
+			w.Write([]byte("handled"))
+		})
+
+	w := doRequest(t, route, http.MethodGet, "/files/missing.txt")
+	if w.Code != http.StatusNotFound || w.Body.String() != "handled" {
+		t.Fatalf("expected the error handler to answer the 404, got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestFile_AttachmentAndContentType(t *testing.T) {
+	route := routeOf(http.MethodGet, "/files/{name...}", func(r *http.Request) result.Result {
+		return result.FileBytes("report", []byte("%PDF-1.7")).
+			WithContentType("application/pdf").
+			WithAttachment("informe año.pdf")
+	})
+
+	w := doRequest(t, route, http.MethodGet, "/files/report")
+	if content := w.Header().Get("Content-Type"); content != "application/pdf" {
+		t.Fatalf("unexpected content type %q", content)
+	}
+	if disposition := w.Header().Get("Content-Disposition"); disposition != "attachment; filename*=utf-8''informe%20a%C3%B1o.pdf" {
+		t.Fatalf("unexpected Content-Disposition %q", disposition)
+	}
+}
+
+func TestFile_PathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	public := filepath.Join(dir, "public")
+	if err := os.Mkdir(public, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "secret.txt"), []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(public, "index.txt"), []byte("public"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		res    result.Result
+		status int
+	}{
+		{"dir allowed", result.FileDir(public, "/index.txt"), http.StatusOK},
+		{"dir traversal", result.FileDir(public, "../secret.txt"), http.StatusNotFound},
+		{"fs traversal", result.FileFS(os.DirFS(public), "../secret.txt"), http.StatusNotFound},
+		{"fs directory", result.FileFS(testFiles, "docs"), http.StatusNotFound},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			route := routeOf(http.MethodGet, "/files/{name...}", func(r *http.Request) result.Result {
+				return c.res
+			})
+
+			w := doRequest(t, route, http.MethodGet, "/files/any")
+			if w.Code != c.status {
+				t.Fatalf("expected %d, got %d", c.status, w.Code)
+			}
+			if strings.Contains(w.Body.String(), "secret") {
+				t.Fatal("file outside of the root was served")
+			}
+		})
+	}
+}
+
+func TestDocFile_BinarySchema(t *testing.T) {
+	viewer := swagger.NewViewer()
+	viewer.RegisterRoute(docs.DocOperation{
+		Method: http.MethodGet,
+		Path:   "/report",
+		Responses: docs.DocResponses{
+			docs.StatusOK: docs.DocFile("application/pdf", "The report"),
+		},
+	})
+
+	data := renderOpenAPI(t, viewer)
+
+	content, ok := data.Paths["/report"].Get.Responses["200"].Content["application/pdf"]
+	if !ok || content.Schema == nil || content.Schema.Type != "string" || content.Schema.Format != "binary" {
+		t.Fatalf("expected a binary schema, got %+v", data.Paths["/report"].Get.Responses["200"])
+	}
+}
@@ -0,0 +1,152 @@
+package router_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router"
+)
+
+type testLogin struct {
+	User     string `form:"user"`
+	Password string `form:"password"`
+	Remember bool   `form:"remember"`
+	Attempts int    `form:"attempts" default:"1"`
+}
+
+type testUpload struct {
+	Title  string                  `form:"title"`
+	Cover  *multipart.FileHeader   `form:"cover"`
+	Photos []*multipart.FileHeader `form:"photos"`
+}
+
+func newFormRequest(body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func newMultipartRequest(t *testing.T, fields map[string]string, files map[string][]string) *http.Request {
+	t.Helper()
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	for k, v := range fields {
+		writer.WriteField(k, v)
+	}
+
+	for k, contents := range files {
+		for i, content := range contents {
+			part, err := writer.CreateFormFile(k, k+string(rune('a'+i))+".txt")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			part.Write([]byte(content))
+		}
+	}
+
+	writer.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/", body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	return r
+}
+
+func TestInputForm(t *testing.T) {
+	login, res := router.InputForm[testLogin](newFormRequest("user=alice&password=secret&remember=true"))
+	if res != nil {
+		t.Fatalf("unexpected error: %v", res.Payload())
+	}
+
+	if login.User != "alice" || login.Password != "secret" || !login.Remember || login.Attempts != 1 {
+		t.Fatalf("unexpected login: %+v", login)
+	}
+}
+
+func TestInputForm_Invalid(t *testing.T) {
+	_, res := router.InputForm[testLogin](newFormRequest("remember=maybe"))
+	if res == nil || res.Status() != http.StatusBadRequest {
+		t.Fatal("expected 400 error")
+	}
+}
+
+func TestInputForm_UnsupportedMedia(t *testing.T) {
+	r := newFormRequest("user=alice")
+	r.Header.Set("Content-Type", "application/json")
+
+	_, res := router.InputForm[testLogin](r)
+	if res == nil || res.Status() != http.StatusUnsupportedMediaType {
+		t.Fatal("expected 415 error")
+	}
+}
+
+func TestInputFormWithOpts_StrictLimit(t *testing.T) {
+	opts := router.InputOpts{Limit: 5, Strict: true}
+
+	_, res := router.InputFormWithOpts[testLogin](newRecorder(), newFormRequest("user=alice"), opts)
+	if res == nil || res.Status() != http.StatusRequestEntityTooLarge {
+		t.Fatal("expected 413 error")
+	}
+}
+
+func TestInputMultipart(t *testing.T) {
+	r := newMultipartRequest(t,
+		map[string]string{"title": "holidays"},
+		map[string][]string{
+			"cover":  {"cover"},
+			"photos": {"one", "two"},
+		},
+	)
+
+	upload, res := router.InputMultipart[testUpload](r)
+	if res != nil {
+		t.Fatalf("unexpected error: %v", res.Payload())
+	}
+
+	if upload.Title != "holidays" || upload.Cover == nil || len(upload.Photos) != 2 {
+		t.Fatalf("unexpected upload: %+v", upload)
+	}
+
+	file, err := upload.Cover.Open()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer file.Close()
+
+	content, _ := io.ReadAll(file)
+	if string(content) != "cover" {
+		t.Fatalf("unexpected content %q", string(content))
+	}
+}
+
+func TestInputMultipartWithOpts_FileLimit(t *testing.T) {
+	r := newMultipartRequest(t, nil, map[string][]string{
+		"photos": {"small", strings.Repeat("x", 64)},
+	})
+
+	opts := router.InputOpts{FileLimit: 32}
+
+	_, res := router.InputMultipartWithOpts[testUpload](newRecorder(), r, opts)
+	if res == nil || res.Status() != http.StatusRequestEntityTooLarge {
+		t.Fatal("expected 413 error")
+	}
+}
+
+func TestInputMultipartWithOpts_TotalLimit(t *testing.T) {
+	r := newMultipartRequest(t, nil, map[string][]string{
+		"photos": {strings.Repeat("x", 1024)},
+	})
+
+	opts := router.InputOpts{Limit: 128}
+
+	_, res := router.InputMultipartWithOpts[testUpload](newRecorder(), r, opts)
+	if res == nil || res.Status() != http.StatusRequestEntityTooLarge {
+		t.Fatal("expected 413 error")
+	}
+}
@@ -0,0 +1,115 @@
+package router_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+func traceHandler(name string) router.RequestHandler {
+	return func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+		trace := ctx.Getz("trace").Stringd("")
+		ctx.Put("trace", trace+name+";")
+		return result.Next()
+	}
+}
+
+func echoTraceHandler(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+	return result.TextOk(ctx.Getz("trace").Stringd("") + "handler")
+}
+
+func TestGroup_NestedPrefixAndContextualizers(t *testing.T) {
+	route := router.NewRouter().
+		BasePath("/api").
+		Group("/users", func(g *router.Group) {
+			g.GroupContextualizer(traceHandler("users"))
+			g.Route(http.MethodGet, echoTraceHandler, "/list")
+
+			g.Group("/admin", func(g *router.Group) {
+				g.GroupContextualizer(traceHandler("admin"))
+				g.Route(http.MethodGet, echoTraceHandler, "/{%s}", "id")
+			})
+		})
+
+	if body := doRequest(t, route, http.MethodGet, "/api/users/list").Body.String(); body != "users;handler" {
+		t.Fatalf("unexpected body %q", body)
+	}
+
+	if body := doRequest(t, route, http.MethodGet, "/api/users/admin/7").Body.String(); body != "users;admin;handler" {
+		t.Fatalf("unexpected body %q", body)
+	}
+}
+
+func TestGroup_ContextualizerErrorStopsHandler(t *testing.T) {
+	called := 0
+	route := router.NewRouter().
+		Group("/secure", func(g *router.Group) {
+			g.GroupContextualizer(errHandler(http.StatusUnauthorized, &called))
+			g.Route(http.MethodGet, okHandler(&called), "/resource")
+		})
+
+	w := doRequest(t, route, http.MethodGet, "/secure/resource")
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", w.Code)
+	}
+
+	if called != 1 {
+		t.Fatalf("expected only the contextualizer to be executed, got %d calls", called)
+	}
+}
+
+func TestGroup_InheritsErrorHandler(t *testing.T) {
+	called := 0
+	route := router.NewRouter().
+		Group("/v1", func(g *router.Group) {
+			g.ErrorHandler(func(w http.ResponseWriter, r *http.Request, ctx *router.Context, res result.Result) {
+				http.Error(w, "group error", res.Status())
+			})
+
+			g.Group("/items", func(g *router.Group) {
+				g.Route(http.MethodGet, errHandler(http.StatusConflict, &called), "")
+			})
+		})
+
+	w := doRequest(t, route, http.MethodGet, "/v1/items")
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "group error") {
+		t.Fatalf("unexpected response: %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestGroup_Cors(t *testing.T) {
+	cors := router.EmptyCors().
+		AllowedOrigins("https://admin.example.com").
+		AllowedMethods(http.MethodDelete)
+
+	route := router.NewRouter().
+		Cors(router.PermissiveCors()).
+		Route(http.MethodGet, textHandler("public"), "/public").
+		Group("/admin", func(g *router.Group) {
+			g.Cors(cors)
+			g.Route(http.MethodDelete, textHandler("deleted"), "/item")
+		})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodOptions, "/admin/item", nil)
+	r.Header.Set("Origin", "https://evil.example.com")
+	r.Header.Set("Access-Control-Request-Method", http.MethodDelete)
+	route.ServeHTTP(w, r)
+
+	if origin := w.Header().Get("Access-Control-Allow-Origin"); origin != "https://admin.example.com" {
+		t.Fatalf("expected group CORS origin, got %q", origin)
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/public", nil)
+	r.Header.Set("Origin", "https://evil.example.com")
+	route.ServeHTTP(w, r)
+
+	if origin := w.Header().Get("Access-Control-Allow-Origin"); origin != "https://evil.example.com" {
+		t.Fatalf("expected router CORS origin, got %q", origin)
+	}
+}
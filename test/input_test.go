@@ -0,0 +1,95 @@
+package router_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/docs"
+)
+
+type testContact struct {
+	Name  string `json:"name" xml:"name" form:"name"`
+	Email string `json:"email" xml:"email" form:"email"`
+}
+
+type upperDecoder struct{}
+
+func (d *upperDecoder) Decode(raw []byte, payload any) error {
+	contact := payload.(*testContact)
+	contact.Name = strings.ToUpper(string(raw))
+	return nil
+}
+
+func newTypedRequest(contentType, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	return r
+}
+
+func TestInput_DispatchByContentType(t *testing.T) {
+	cases := map[string]string{
+		"application/json; charset=utf-8":   `{"name":"Ada","email":"ada@mail.com"}`,
+		"application/xml":                   `<testContact><name>Ada</name><email>ada@mail.com</email></testContact>`,
+		"text/xml":                          `<testContact><name>Ada</name><email>ada@mail.com</email></testContact>`,
+		"application/x-www-form-urlencoded": `name=Ada&email=ada%40mail.com`,
+	}
+
+	for contentType, body := range cases {
+		contact, res := router.Input[testContact](newTypedRequest(contentType, body))
+		if res != nil {
+			t.Fatalf("%s: unexpected error: %v", contentType, res.Payload())
+		}
+		if contact.Name != "Ada" || contact.Email != "ada@mail.com" {
+			t.Fatalf("%s: unexpected contact: %+v", contentType, contact)
+		}
+	}
+}
+
+func TestInput_UnsupportedMediaType(t *testing.T) {
+	for _, contentType := range []string{"", "application/octet-stream", "invalid;;"} {
+		_, res := router.Input[testContact](newTypedRequest(contentType, "data"))
+		if res == nil || res.Status() != http.StatusUnsupportedMediaType {
+			t.Fatalf("%q: expected 415, got %v", contentType, res)
+		}
+	}
+}
+
+func TestInput_InvalidBody(t *testing.T) {
+	_, res := router.Input[testContact](newTypedRequest("application/json", `{"name":`))
+	if res == nil || res.Status() != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %v", res)
+	}
+}
+
+func TestInput_RegisteredDecoder(t *testing.T) {
+	router.RegisterDecoder("text/x-upper", &upperDecoder{})
+
+	contact, res := router.Input[testContact](newTypedRequest("text/x-upper", "ada"))
+	if res != nil {
+		t.Fatalf("unexpected error: %v", res.Payload())
+	}
+	if contact.Name != "ADA" {
+		t.Fatalf("expected 'ADA', got %q", contact.Name)
+	}
+
+	if !slices.Contains(router.Decoders(), "text/x-upper") {
+		t.Fatalf("expected registered media type in %v", router.Decoders())
+	}
+}
+
+func TestDocInputPayload(t *testing.T) {
+	payload := router.DocInputPayload[testContact]("contact")
+
+	if payload.MediaType != docs.JSON {
+		t.Fatalf("expected main media type %s, got %s", docs.JSON, payload.MediaType)
+	}
+	if !slices.Contains(payload.Accepts, docs.XML) || !slices.Contains(payload.Accepts, docs.FORM) {
+		t.Fatalf("expected XML and form in accepted types, got %v", payload.Accepts)
+	}
+}
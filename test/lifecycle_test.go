@@ -0,0 +1,179 @@
+package router_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/result"
+	"github.com/Rafael24595/go-web/router/websocket"
+)
+
+func TestRouter_ShutdownDrainsRequests(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entered := make(chan struct{})
+	started := make(chan string, 1)
+	stopped := make(chan struct{}, 1)
+
+	slow := func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+		close(entered)
+		time.Sleep(100 * time.Millisecond)
+		return result.TextOk("done")
+	}
+
+	route := router.NewRouter().
+		Route(http.MethodGet, slow, "/slow").
+		OnStart(func(addr string) {
+			started <- addr
+		}).
+		OnShutdown(func(ctx context.Context) error {
+			stopped <- struct{}{}
+			return nil
+		})
+
+	served := make(chan error, 1)
+	go func() {
+		served <- route.Serve(listener)
+	}()
+
+	addr := <-started
+
+	response := make(chan string, 1)
+	go func() {
+		res, err := http.Get("http://" + addr + "/slow")
+		if err != nil {
+			response <- err.Error()
+			return
+		}
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		response <- string(body)
+	}()
+
+	<-entered
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := route.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+
+	if body := <-response; body != "done" {
+		t.Fatalf("expected in-flight request to complete, got %q", body)
+	}
+
+	if err := <-served; err != nil {
+		t.Fatalf("expected nil from Serve after shutdown, got %v", err)
+	}
+
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("expected shutdown hook to be executed")
+	}
+}
+
+func TestRouter_ShutdownEndsLongLivedHandlers(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events := make(chan result.Event)
+	route := router.NewRouter().
+		Route(http.MethodGet, func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			return result.SSE(events)
+		}, "/events").
+		WebSocket(echoSocket, "/echo")
+
+	served := make(chan error, 1)
+	go func() {
+		served <- route.Serve(listener)
+	}()
+
+	server := &httptest.Server{URL: "http://" + listener.Addr().String()}
+
+	stream, err := http.Get(server.URL + "/events")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Body.Close()
+
+	socket, _ := dialSocket(t, server, "/echo", nil)
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- route.Shutdown(context.Background())
+	}()
+
+	select {
+	case err := <-shutdown:
+		if err != nil {
+			t.Fatalf("unexpected shutdown error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the shutdown to end the stream and the socket")
+	}
+
+	socket.expectClose(websocket.CloseGoingAway)
+
+	if _, err := io.ReadAll(stream.Body); err != nil {
+		t.Fatalf("expected the stream to end cleanly, got %v", err)
+	}
+	if err := <-served; err != nil {
+		t.Fatalf("expected nil from Serve after shutdown, got %v", err)
+	}
+}
+
+func TestRouter_ShutdownIdempotent(t *testing.T) {
+	route := router.NewRouter()
+
+	if err := route.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := route.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error on second shutdown: %v", err)
+	}
+}
+
+func TestRouter_ServerOptions(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	route := router.NewRouter().
+		Server(router.ServerOptions{
+			ReadHeaderTimeout: time.Second,
+			MaxHeaderBytes:    1,
+		}).
+		Route(http.MethodGet, textHandler("hello"), "/hello")
+
+	go route.Serve(listener)
+	defer route.Shutdown(context.Background())
+
+	req, _ := http.NewRequest(http.MethodGet, "http://"+listener.Addr().String()+"/hello", nil)
+	req.Header.Set("X-Large", strings.Repeat("a", 8<<10))
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusRequestHeaderFieldsTooLarge {
+		t.Fatalf("expected status 431, got %d", res.StatusCode)
+	}
+}
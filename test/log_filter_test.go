@@ -0,0 +1,112 @@
+package router_test
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router/log"
+)
+
+func TestFilter_LevelsAndCategories(t *testing.T) {
+	buffer := &syncBuffer{}
+	logger := log.NewFilter(log.NewAsyncLogger(log.AsyncOpts{Writer: buffer})).
+		Level(slog.LevelWarn).
+		CategoryLevel("AUDIT", slog.LevelError).
+		Enable("swagger").
+		Disable(log.ERROR)
+
+	logger.Message("hidden message")
+	logger.Warning("shown warning")
+	logger.Errors("hidden error")
+	logger.Custom("SWAGGER", "shown swagger")
+	logger.Custom("DEV-REQUEST", "hidden trace")
+	logger.Custom("audit", "shown audit")
+	logger.Flush()
+
+	lines := buffer.Lines()
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %v", lines)
+	}
+	for _, expected := range []string{"[WARNING]: shown warning", "[SWAGGER]: shown swagger", "[AUDIT]: shown audit"} {
+		found := false
+		for _, line := range lines {
+			found = found || strings.HasSuffix(line, expected)
+		}
+		if !found {
+			t.Fatalf("expected %q in %v", expected, lines)
+		}
+	}
+}
+
+func TestFilter_Route(t *testing.T) {
+	main := &syncBuffer{}
+	access := &syncBuffer{}
+
+	logger := log.NewFilter(log.NewAsyncLogger(log.AsyncOpts{Writer: main})).
+		Route(log.NewAsyncLogger(log.AsyncOpts{Writer: access}), "ACCESS")
+
+	logger.Message("main record")
+	logger.Custom("access", "access record")
+	logger.Close()
+
+	if lines := main.Lines(); len(lines) != 1 || !strings.HasSuffix(lines[0], "main record") {
+		t.Fatalf("unexpected main output %v", lines)
+	}
+	if lines := access.Lines(); len(lines) != 1 || !strings.HasSuffix(lines[0], "[ACCESS]: access record") {
+		t.Fatalf("unexpected access output %v", lines)
+	}
+}
+
+func TestNewLogger_Options(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "logs", "app.log")
+	second := filepath.Join(dir, "copy.log")
+
+	logger, err := log.NewLogger(log.LoggerOpts{
+		Level:   "debug",
+		Format:  "json",
+		Sinks:   []string{first, second},
+		Disable: []string{"DEV-REQUEST"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger.Custom(log.DEBUG, "debug record")
+	logger.Custom("DEV-REQUEST", "hidden trace")
+
+	for _, path := range []string{first, second} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content := string(data)
+		if !strings.Contains(content, `"msg":"debug record"`) || strings.Contains(content, "hidden trace") {
+			t.Fatalf("unexpected content of %s: %q", path, content)
+		}
+	}
+
+	if _, err := log.NewLogger(log.LoggerOpts{Level: "verbose"}); err == nil {
+		t.Fatal("expected an error for an invalid level")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	cases := map[string]slog.Level{
+		"debug":   slog.LevelDebug,
+		"INFO":    slog.LevelInfo,
+		"warning": slog.LevelWarn,
+		"error":   slog.LevelError,
+		"info+2":  slog.LevelInfo + 2,
+	}
+
+	for name, expected := range cases {
+		level, err := log.ParseLevel(name)
+		if err != nil || level != expected {
+			t.Fatalf("unexpected level for %q: %v %v", name, level, err)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package router_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/log"
+)
+
+func decodeRecords(t *testing.T, buffer *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	records := make([]map[string]any, 0)
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		record := make(map[string]any)
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid JSON record %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestSlogLogger_Levels(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := log.NewSlogLogger(log.SlogOpts{
+		Format: log.JSON,
+		Level:  slog.LevelWarn,
+		Writer: &buffer,
+		Attrs:  []slog.Attr{slog.String("service", "catalog")},
+	})
+
+	logger.Message("hidden")
+	logger.Warningf("cache at %d%%", 90)
+	logger.Errors("failure")
+	logger.Customf("swagger", "route %s", "/users")
+
+	records := decodeRecords(t, &buffer)
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records over the warn level, got %d: %v", len(records), records)
+	}
+	if records[0]["level"] != "WARN" || records[0]["msg"] != "cache at 90%" || records[0]["service"] != "catalog" {
+		t.Fatalf("unexpected warning record %v", records[0])
+	}
+	if records[1]["level"] != "ERROR" || records[1]["msg"] != "failure" {
+		t.Fatalf("unexpected error record %v", records[1])
+	}
+}
+
+func TestSlogLogger_Attributes(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := log.SlogLogger(slog.New(slog.NewJSONHandler(&buffer, nil))).
+		With(slog.String(log.REQUEST_ID, "abc"))
+
+	log.Attrs(logger, "audit", "user updated", slog.Int(log.STATUS, 200))
+
+	records := decodeRecords(t, &buffer)
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	record := records[0]
+	if record[log.CATEGORY] != "AUDIT" || record[log.REQUEST_ID] != "abc" || record[log.STATUS] != float64(200) {
+		t.Fatalf("unexpected record %v", record)
+	}
+}
+
+func TestSlogLogger_Source(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := log.NewSlogLogger(log.SlogOpts{
+		Format:    log.JSON,
+		Writer:    &buffer,
+		AddSource: true,
+	})
+
+	logger.Message("direct")
+	log.Attrs(log.NewFilter(logger), "audit", "filtered")
+
+	records := decodeRecords(t, &buffer)
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	for _, record := range records {
+		source, _ := record[slog.SourceKey].(map[string]any)
+		file, _ := source["file"].(string)
+		function, _ := source["function"].(string)
+		if !strings.HasSuffix(file, "log_test.go") || !strings.HasSuffix(function, "TestSlogLogger_Source") {
+			t.Fatalf("expected the caller as source, got %v", source)
+		}
+	}
+}
+
+func TestSlogLogger_Router(t *testing.T) {
+	var buffer bytes.Buffer
+	route := router.NewRouter().
+		Logger(log.SlogLogger(slog.New(slog.NewJSONHandler(&buffer, nil)))).
+		Contextualizer(func(w http.ResponseWriter, r *http.Request) (*router.Context, error) {
+			return nil, errors.New("session expired")
+		}).
+		Route(http.MethodGet, textHandler("ok"), "/users")
+
+	doRequest(t, route, http.MethodGet, "/users")
+
+	records := decodeRecords(t, &buffer)
+	if len(records) != 1 || records[0]["level"] != "ERROR" || records[0]["msg"] != "session expired" {
+		t.Fatalf("unexpected records %v", records)
+	}
+}
@@ -0,0 +1,95 @@
+package router_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+func traceMiddleware(trace *[]string, name string) router.Middleware {
+	return func(next router.RequestHandler) router.RequestHandler {
+		return func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			*trace = append(*trace, name+":in")
+			res := next(w, r, ctx)
+			*trace = append(*trace, name+":out")
+			return res
+		}
+	}
+}
+
+func TestMiddleware_Order(t *testing.T) {
+	trace := make([]string, 0)
+
+	guard := func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+		trace = append(trace, "guard")
+		return result.Next()
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+		trace = append(trace, "handler")
+		return result.TextOk("ok")
+	}
+
+	options := router.NewHandlerOptions(handler).
+		Use(traceMiddleware(&trace, "route"))
+
+	route := router.NewRouter().
+		Group("/api", func(g *router.Group) {
+			g.Use(traceMiddleware(&trace, "parent"))
+			g.GroupContextualizer(guard)
+			g.Group("/v1", func(g *router.Group) {
+				g.Use(traceMiddleware(&trace, "child"))
+				g.RouteWithOptions(http.MethodGet, options, "/items")
+			})
+		}).
+		Use(traceMiddleware(&trace, "global"))
+
+	doRequest(t, route, http.MethodGet, "/api/v1/items")
+
+	expected := "global:in,parent:in,child:in,guard,route:in,handler,route:out,child:out,parent:out,global:out"
+	if got := strings.Join(trace, ","); got != expected {
+		t.Fatalf("unexpected order:\n  got:      %s\n  expected: %s", got, expected)
+	}
+}
+
+func TestMiddleware_ShortCircuitAndTransform(t *testing.T) {
+	called := 0
+
+	deny := func(next router.RequestHandler) router.RequestHandler {
+		return func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			if r.Header.Get("Authorization") == "" {
+				return result.Reject(http.StatusUnauthorized)
+			}
+			return next(w, r, ctx)
+		}
+	}
+
+	upper := func(next router.RequestHandler) router.RequestHandler {
+		return func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			res := next(w, r, ctx)
+			if text, ok := res.Payload().(string); ok && res.Ok() {
+				return result.TextOk(strings.ToUpper(text))
+			}
+			return res
+		}
+	}
+
+	route := router.NewRouter().
+		Use(upper, deny).
+		Route(http.MethodGet, func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			called++
+			return result.TextOk("hello")
+		}, "/hello")
+
+	if w := doRequest(t, route, http.MethodGet, "/hello"); w.Code != http.StatusUnauthorized || called != 0 {
+		t.Fatalf("expected short circuit, got %d with %d calls", w.Code, called)
+	}
+
+	w := doRequest(t, route, http.MethodGet, "/hello", map[string]string{"Authorization": "token"})
+	if w.Body.String() != "HELLO" {
+		t.Fatalf("expected transformed body, got %q", w.Body.String())
+	}
+}
@@ -0,0 +1,117 @@
+package router_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/docs"
+	"github.com/Rafael24595/go-web/router/docs/swagger"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+func negotiateRouter() *router.Router {
+	return routeOf(http.MethodGet, "/product", func(r *http.Request) result.Result {
+		return result.Negotiate(testProduct{ID: 1, Name: "Book", Price: "12.5"})
+	})
+}
+
+func TestNegotiate_Accept(t *testing.T) {
+	route := negotiateRouter()
+
+	cases := map[string]string{
+		"":                "application/json",
+		"*/*":             "application/json",
+		"application/xml": "application/xml",
+		"text/*":          "text/xml",
+		"application/json;q=0.5, application/xml": "application/xml",
+		"application/xml;q=0.2, */*;q=0.8":        "application/json",
+		"text/html, application/*;q=0.1":          "application/json",
+	}
+
+	for accept, expected := range cases {
+		w := doRequest(t, route, http.MethodGet, "/product", map[string]string{"Accept": accept})
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%q: expected 200, got %d", accept, w.Code)
+		}
+		if content := w.Header().Get("Content-Type"); content != expected {
+			t.Fatalf("%q: expected %s, got %s", accept, expected, content)
+		}
+		if w.Header().Get("Vary") != "Accept" {
+			t.Fatalf("%q: expected Vary: Accept", accept)
+		}
+	}
+
+	w := doRequest(t, route, http.MethodGet, "/product", map[string]string{"Accept": "application/xml"})
+	if !strings.Contains(w.Body.String(), "<name>Book</name>") {
+		t.Fatalf("expected XML body, got %q", w.Body.String())
+	}
+}
+
+func TestNegotiate_NotAcceptable(t *testing.T) {
+	route := negotiateRouter()
+
+	for _, accept := range []string{"text/html", "application/json;q=0, application/xml;q=0, text/xml;q=0"} {
+		w := doRequest(t, route, http.MethodGet, "/product", map[string]string{"Accept": accept})
+		if w.Code != http.StatusNotAcceptable {
+			t.Fatalf("%q: expected 406, got %d", accept, w.Code)
+		}
+		if content := w.Header().Get("Content-Type"); content != "application/problem+json" {
+			t.Fatalf("%q: expected problem body, got %s", accept, content)
+		}
+	}
+}
+
+func TestNegotiate_RegisteredEncoder(t *testing.T) {
+	result.RegisterEncoder("text/x-custom", result.NewTextEncoder())
+
+	route := router.NewRouter().
+		Route(http.MethodGet, func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			return result.Negotiates(http.StatusAccepted, "custom")
+		}, "/custom")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/custom", nil)
+	r.Header.Set("Accept", "text/x-custom")
+	route.ServeHTTP(w, r)
+
+	if w.Code != http.StatusAccepted || w.Header().Get("Content-Type") != "text/x-custom" || w.Body.String() != "custom" {
+		t.Fatalf("unexpected response: %d %s %q", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+	}
+}
+
+func TestNegotiate_NotAcceptableUsesErrorHandler(t *testing.T) {
+	route := negotiateRouter().
+		ErrorHandler(func(w http.ResponseWriter, r *http.Request, ctx *router.Context, res result.Result) {
+			w.WriteHeader(res.Status())
+			w.Write([]byte("handled"))
+		})
+
+	w := doRequest(t, route, http.MethodGet, "/product", map[string]string{"Accept": "text/html"})
+	if w.Code != http.StatusNotAcceptable || w.Body.String() != "handled" {
+		t.Fatalf("expected the error handler to answer the 406, got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestDocNegotiatePayload(t *testing.T) {
+	viewer := swagger.NewViewer()
+	viewer.RegisterRoute(docs.DocOperation{
+		Method: http.MethodGet,
+		Path:   "/product",
+		Responses: docs.DocResponses{
+			docs.StatusOK: docs.DocNegotiatePayload[testProduct]("Product"),
+		},
+	})
+
+	data := renderOpenAPI(t, viewer)
+
+	content := data.Paths["/product"].Get.Responses["200"].Content
+	for _, media := range result.Encoders() {
+		if _, ok := content[media]; !ok {
+			t.Fatalf("expected %s in response content, got %v", media, content)
+		}
+	}
+}
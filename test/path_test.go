@@ -0,0 +1,115 @@
+package router_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/docs"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+func newPathRequest(name, value string) *http.Request {
+	r := newRequest("")
+	r.SetPathValue(name, value)
+	return r
+}
+
+func TestPathInt(t *testing.T) {
+	id, res := router.PathInt(newPathRequest("id", "42"), "id")
+	if res != nil {
+		t.Fatalf("unexpected error: %v", res.Payload())
+	}
+	if id != 42 {
+		t.Fatalf("expected 42, got %d", id)
+	}
+}
+
+func TestPathInt_Invalid(t *testing.T) {
+	_, res := router.PathInt(newPathRequest("id", "abc"), "id")
+	if res == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if res.Status() != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", res.Status())
+	}
+}
+
+func TestPath_Missing(t *testing.T) {
+	_, res := router.Path[string](newRequest(""), "id")
+	if res == nil || res.Status() != http.StatusBadRequest {
+		t.Fatal("expected 400 error for missing parameter")
+	}
+}
+
+func TestPath_Generic(t *testing.T) {
+	duration, res := router.Path[time.Duration](newPathRequest("ttl", "1m30s"), "ttl")
+	if res != nil {
+		t.Fatalf("unexpected error: %v", res.Payload())
+	}
+	if duration != 90*time.Second {
+		t.Fatalf("expected 1m30s, got %s", duration)
+	}
+
+	ratio, res := router.Path[float64](newPathRequest("ratio", "0.5"), "ratio")
+	if res != nil || ratio != 0.5 {
+		t.Fatalf("unexpected ratio %v (%v)", ratio, res)
+	}
+}
+
+func TestPathUUID(t *testing.T) {
+	raw := "123E4567-E89B-12D3-A456-426614174000"
+
+	id, res := router.PathUUID(newPathRequest("id", raw), "id")
+	if res != nil {
+		t.Fatalf("unexpected error: %v", res.Payload())
+	}
+	if id != "123e4567-e89b-12d3-a456-426614174000" {
+		t.Fatalf("unexpected uuid %q", id)
+	}
+
+	if _, res := router.PathUUID(newPathRequest("id", "123e4567"), "id"); res == nil {
+		t.Fatal("expected error for invalid uuid")
+	}
+}
+
+func TestPath_ThroughRouter(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+		id, res := router.PathInt(r, "id")
+		if res != nil {
+			return *res
+		}
+		return result.TextOk(fmt.Sprintf("item %d", id+1))
+	}
+
+	route := router.NewRouter().
+		RouteDocument(http.MethodGet, handler, "/items/{%s}", docs.DocRoute{
+			Parameters: docs.DocOrderParameters{
+				docs.ParameterInt("id", "Item identifier"),
+			},
+		})
+
+	if body := doRequest(t, route, http.MethodGet, "/items/41").Body.String(); body != "item 42" {
+		t.Fatalf("unexpected body %q", body)
+	}
+
+	invalid := doRequest(t, route, http.MethodGet, "/items/x")
+	if invalid.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", invalid.Code)
+	}
+	if content := invalid.Header().Get("Content-Type"); content != "application/problem+json" {
+		t.Fatalf("expected a problem, got %q", content)
+	}
+}
+
+func TestTypedParameter(t *testing.T) {
+	if p := docs.TypedParameter[uint16]("page", ""); p.Type != "integer" || p.Format != "int32" {
+		t.Fatalf("unexpected parameter type %+v", p)
+	}
+
+	if p := docs.ParameterUUID("id", ""); p.Type != "string" || p.Format != "uuid" {
+		t.Fatalf("unexpected parameter type %+v", p)
+	}
+}
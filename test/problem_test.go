@@ -0,0 +1,160 @@
+package router_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/docs"
+	"github.com/Rafael24595/go-web/router/docs/swagger"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+func TestProblem_Marshal(t *testing.T) {
+	problem := result.NewProblem(http.StatusConflict, "user already exists").
+		WithType("https://example.com/problems/conflict").
+		WithInstance("/users/7").
+		WithExtension("user", 7).
+		WithExtension("status", "ignored")
+
+	raw, err := json.Marshal(problem)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded result.Problem
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded.Status != http.StatusConflict || decoded.Title != "Conflict" || decoded.Detail != "user already exists" {
+		t.Fatalf("unexpected problem: %+v", decoded)
+	}
+	if decoded.Type != "https://example.com/problems/conflict" || decoded.Instance != "/users/7" {
+		t.Fatalf("unexpected problem: %+v", decoded)
+	}
+	if decoded.Extensions["user"] != float64(7) {
+		t.Fatalf("expected extension 'user', got %v", decoded.Extensions)
+	}
+	if _, ok := decoded.Extensions["status"]; ok {
+		t.Fatalf("extension must not override standard members: %v", decoded.Extensions)
+	}
+}
+
+func TestProblemErr_Response(t *testing.T) {
+	route := router.NewRouter().
+		Route(http.MethodGet, func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			return result.ProblemErr(http.StatusBadRequest, errors.New("first"), nil, errors.New("second"))
+		}, "/fail")
+
+	w := doRequest(t, route, http.MethodGet, "/fail")
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	if content := w.Header().Get("Content-Type"); content != "application/problem+json" {
+		t.Fatalf("expected problem content type, got %q", content)
+	}
+
+	var problem result.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if problem.Detail != "first\nsecond" || problem.Type != result.PROBLEM_TYPE_DEFAULT {
+		t.Fatalf("unexpected problem: %+v", problem)
+	}
+}
+
+func TestErr_JoinsLines(t *testing.T) {
+	res := result.Err(http.StatusBadRequest, errors.New("first"), errors.New("second"))
+	if res.Payload() != "first\nsecond" {
+		t.Fatalf("unexpected payload: %q", res.Payload())
+	}
+}
+
+func TestInput_ProblemOnFailure(t *testing.T) {
+	_, res := router.Input[testContact](newTypedRequest("application/octet-stream", "data"))
+	if res == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	problem, ok := res.Payload().(result.Problem)
+	if !ok || problem.Status != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected 415 problem, got %v", res.Payload())
+	}
+	if content := res.Encoder().Headers()["Content-Type"]; content != "application/problem+json" {
+		t.Fatalf("unexpected content type %q", content)
+	}
+}
+
+func TestDocProblem_SharedSchema(t *testing.T) {
+	factory := swagger.NewFactoryStructToSchema()
+
+	payload := docs.DocProblem("error")
+	first, err := factory.MakeSchema(payload.MediaType, payload.Payload)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, _ := factory.MakeSchema(payload.MediaType, payload.Payload)
+
+	if first.Ref == "" || first.Ref != second.Ref || !strings.Contains(first.Ref, "Problem") {
+		t.Fatalf("expected shared problem reference, got %q and %q", first.Ref, second.Ref)
+	}
+
+	for _, schema := range factory.Components().Schemas {
+		if _, ok := schema.Properties["Extensions"]; ok {
+			t.Fatal("ignored field must not be documented")
+		}
+		if _, ok := schema.Properties["status"]; !ok {
+			t.Fatalf("expected status property, got %v", schema.Properties)
+		}
+	}
+}
+
+func TestDocProblem_GeneratedResponses(t *testing.T) {
+	viewer := swagger.NewViewer()
+	viewer.RegisterRoute(docs.DocOperation{
+		Method:  http.MethodPost,
+		Path:    "/users",
+		Request: docs.DocJsonPayload[testUser](),
+		Responses: docs.DocResponses{
+			docs.StatusCreated:    docs.DocNegotiatePayload[testUser]("Created"),
+			docs.StatusBadRequest: docs.DocProblem("Invalid user"),
+		},
+	})
+	viewer.RegisterRoute(docs.DocOperation{
+		Method: http.MethodGet,
+		Path:   "/users",
+		Responses: docs.DocResponses{
+			docs.StatusOK: docs.DocJsonPayload[[]testUser]("Users").WithHeader("ETag", "Version"),
+		},
+	})
+
+	data := renderOpenAPI(t, viewer)
+
+	if _, ok := data.Components.Schemas[swagger.PROBLEM_SCHEMA]; !ok {
+		t.Fatalf("expected the Problem component, got %v", data.Components.Schemas)
+	}
+
+	post := data.Paths["/users"].Post.Responses
+	for _, status := range []string{"400", "406", "415", "422"} {
+		content, ok := post[status].Content[string(docs.PROBLEM)]
+		if !ok || content.Schema == nil || content.Schema.Ref != "#/components/schemas/Problem" {
+			t.Fatalf("expected a Problem reference for %s, got %+v", status, post[status])
+		}
+	}
+	if post["400"].Description != "Invalid user" {
+		t.Fatalf("expected the documented 400 to be kept, got %q", post["400"].Description)
+	}
+	if _, ok := post["412"]; ok {
+		t.Fatal("unexpected 412 without validators")
+	}
+
+	get := data.Paths["/users"].Get.Responses
+	if _, ok := get["412"]; !ok || len(get) != 2 {
+		t.Fatalf("expected only 200 and 412, got %v", get)
+	}
+}
@@ -0,0 +1,126 @@
+package router_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/docs"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+type testLevel int
+
+func (l *testLevel) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return http.ErrNotSupported
+	}
+	return nil
+}
+
+type testPaging struct {
+	Page int `query:"page" default:"1" description:"Page number"`
+	Size int `query:"size" default:"20"`
+}
+
+type testSearch struct {
+	testPaging
+	Term    string        `query:"q" description:"Search term"`
+	Tags    []string      `query:"tag"`
+	Ids     []int         `query:"id"`
+	Since   *time.Time    `query:"since" layout:"2006-01-02"`
+	Limit   *int          `query:"limit"`
+	TTL     time.Duration `query:"ttl" default:"1m"`
+	Level   testLevel     `query:"level"`
+	Ignored string        `query:"-"`
+}
+
+func newQueryRequest(query string) *http.Request {
+	return httptest.NewRequest(http.MethodGet, "/search?"+query, nil)
+}
+
+func TestInputQuery(t *testing.T) {
+	req := newQueryRequest("q=go&tag=a&tag=b&id=1&id=2&since=2024-01-31&level=high&size=50&Ignored=x")
+
+	search, res := router.InputQuery[testSearch](req)
+	if res != nil {
+		t.Fatalf("unexpected error: %v", res.Payload())
+	}
+
+	if search.Term != "go" || strings.Join(search.Tags, ",") != "a,b" || len(search.Ids) != 2 {
+		t.Fatalf("unexpected search: %+v", search)
+	}
+
+	if search.Since == nil || search.Since.Day() != 31 {
+		t.Fatalf("unexpected since: %v", search.Since)
+	}
+
+	if search.Limit != nil {
+		t.Fatalf("expected nil limit, got %d", *search.Limit)
+	}
+
+	if search.Page != 1 || search.Size != 50 || search.TTL != time.Minute {
+		t.Fatalf("unexpected defaults: %+v", search)
+	}
+
+	if search.Level != 2 || search.Ignored != "" {
+		t.Fatalf("unexpected search: %+v", search)
+	}
+}
+
+func TestInputQuery_Defaults(t *testing.T) {
+	type sorting struct {
+		Order  string   `query:"order" default:"name,asc"`
+		Fields []string `query:"field" default:"id,name"`
+	}
+
+	sort, res := router.InputQuery[sorting](newQueryRequest(""))
+	if res != nil {
+		t.Fatalf("unexpected error: %v", res.Payload())
+	}
+
+	if sort.Order != "name,asc" || strings.Join(sort.Fields, "|") != "id|name" {
+		t.Fatalf("unexpected defaults: %+v", sort)
+	}
+}
+
+func TestInputQuery_InvalidFields(t *testing.T) {
+	req := newQueryRequest("page=x&id=1&id=y&level=medium")
+
+	_, res := router.InputQuery[testSearch](req)
+	if res == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if res.Status() != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", res.Status())
+	}
+
+	problem, _ := res.Payload().(result.Problem)
+	message := problem.Detail
+	for _, field := range []string{"page", "id", "level"} {
+		if !strings.Contains(message, field) {
+			t.Fatalf("expected field %q in error message %q", field, message)
+		}
+	}
+}
+
+func TestDocStructParameters(t *testing.T) {
+	parameters := docs.DocStructParameters[testSearch]("query")
+
+	if parameters["q"] != "Search term" || parameters["page"] != "Page number" {
+		t.Fatalf("unexpected parameters: %v", parameters)
+	}
+
+	if _, ok := parameters["Ignored"]; ok {
+		t.Fatal("expected ignored field not to be documented")
+	}
+}
@@ -0,0 +1,112 @@
+package router_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/docs"
+	"github.com/Rafael24595/go-web/router/docs/swagger"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+func renderOpenAPI(t *testing.T, viewer *swagger.OpenAPI3Viewer) swagger.OpenAPI3 {
+	t.Helper()
+
+	for _, h := range viewer.Handlers() {
+		if h.Route != swagger.SWAGGER_JSON {
+			continue
+		}
+
+		w := httptest.NewRecorder()
+		h.Handler(w, httptest.NewRequest(http.MethodGet, h.Route, nil))
+
+		var data swagger.OpenAPI3
+		if err := json.Unmarshal(w.Body.Bytes(), &data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return data
+	}
+
+	t.Fatal("OpenAPI JSON handler not found")
+	return swagger.OpenAPI3{}
+}
+
+func TestResult_HeadersAndCookies(t *testing.T) {
+	route := router.NewRouter().
+		Route(http.MethodPost, func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			return result.JsonOks(http.StatusCreated, map[string]string{"id": "7"}).
+				WithHeader("Location", "/users/7").
+				WithHeader("Cache-Control", "no-store").
+				WithCookie(&http.Cookie{Name: "session", Value: "abc"})
+		}, "/users")
+
+	w := doRequest(t, route, http.MethodPost, "/users")
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", w.Code)
+	}
+	if content := w.Header().Get("Content-Type"); content != "application/json" {
+		t.Fatalf("expected encoder content type, got %q", content)
+	}
+	if location := w.Header().Get("Location"); location != "/users/7" {
+		t.Fatalf("unexpected Location %q", location)
+	}
+	if cache := w.Header().Get("Cache-Control"); cache != "no-store" {
+		t.Fatalf("unexpected Cache-Control %q", cache)
+	}
+	if cookie := w.Header().Get("Set-Cookie"); cookie != "session=abc" {
+		t.Fatalf("unexpected Set-Cookie %q", cookie)
+	}
+}
+
+func TestResult_WithContentType(t *testing.T) {
+	route := router.NewRouter().
+		Route(http.MethodGet, func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			return result.Ok("<p>hi</p>").WithContentType("text/html")
+		}, "/page").
+		Route(http.MethodGet, func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			return result.TextErr(http.StatusTeapot, "short and stout").WithHeader("Retry-After", "10")
+		}, "/teapot")
+
+	if content := doRequest(t, route, http.MethodGet, "/page").Header().Get("Content-Type"); content != "text/html" {
+		t.Fatalf("expected overridden content type, got %q", content)
+	}
+
+	w := doRequest(t, route, http.MethodGet, "/teapot")
+	if w.Code != http.StatusTeapot || w.Header().Get("Retry-After") != "10" {
+		t.Fatalf("unexpected error response: %d %v", w.Code, w.Header())
+	}
+}
+
+func TestResult_BuildersDoNotMutate(t *testing.T) {
+	base := result.Ok("payload").WithHeader("X-Base", "1")
+	derived := base.WithHeader("X-Derived", "2").WithCookie(&http.Cookie{Name: "a", Value: "b"})
+
+	if base.Headers().Get("X-Derived") != "" || len(base.Cookies()) != 0 {
+		t.Fatal("builder methods must not modify the original result")
+	}
+	if derived.Headers().Get("X-Base") != "1" || len(derived.Cookies()) != 1 {
+		t.Fatalf("unexpected derived result: %v %v", derived.Headers(), derived.Cookies())
+	}
+}
+
+func TestDocPayload_ResponseHeaders(t *testing.T) {
+	viewer := swagger.NewViewer()
+	viewer.RegisterRoute(docs.DocOperation{
+		Method: http.MethodPost,
+		Path:   "/users",
+		Responses: docs.DocResponses{
+			docs.StatusCreated: docs.DocText("Created").WithHeader("Location", "URL of the new user"),
+		},
+	})
+
+	data := renderOpenAPI(t, viewer)
+
+	header, ok := data.Paths["/users"].Post.Responses["201"].Headers["Location"]
+	if !ok || header.Description != "URL of the new user" {
+		t.Fatalf("expected documented Location header, got %+v", data.Paths["/users"].Post.Responses["201"])
+	}
+}
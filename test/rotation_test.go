@@ -0,0 +1,209 @@
+package router_test
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Rafael24595/go-web/router/log"
+)
+
+func readGzip(t *testing.T, path string) string {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(reader)
+	return string(data)
+}
+
+func TestRotatingFile_SizeCompressAndCount(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	unrelated := []string{
+		filepath.Join(dir, "app-2020-01-01T00-00-00.000.json"),
+		filepath.Join(dir, "app-2020-01-01T00-00-00.000.log.bak"),
+		filepath.Join(dir, "app-2020-01-01T00-00-00.000.x.log"),
+	}
+	for _, v := range unrelated {
+		if err := os.WriteFile(v, []byte("other\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	file, err := log.NewRotatingFile(path, log.RotationOpts{
+		MaxSize:  10,
+		Compress: true,
+		MaxCount: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	file.Close()
+
+	rotated, err := file.Rotated()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rotated) != 2 {
+		t.Fatalf("expected 2 retained files, got %v", rotated)
+	}
+	for _, v := range rotated {
+		if !strings.HasSuffix(v, ".log.gz") {
+			t.Fatalf("expected a compressed file, got %s", v)
+		}
+	}
+	if content := readGzip(t, rotated[1]); content != "third\n" {
+		t.Fatalf("unexpected rotated content %q", content)
+	}
+
+	current, _ := os.ReadFile(path)
+	if string(current) != "fourth\n" {
+		t.Fatalf("unexpected current content %q", current)
+	}
+
+	for _, v := range unrelated {
+		if !fileExists(v) {
+			t.Fatalf("expected %s to be kept by the retention", v)
+		}
+	}
+}
+
+func TestRotatingFile_DailyAndMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	old := filepath.Join(dir, "app-2000-01-01T00-00-00.000.log")
+	if err := os.WriteFile(old, []byte("ancient\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("yesterday\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	yesterday := time.Now().Add(-24 * time.Hour)
+	if err := os.Chtimes(path, yesterday, yesterday); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := log.NewRotatingFile(path, log.RotationOpts{Daily: true, MaxAge: 7 * 24 * time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Write([]byte("today\n"))
+	file.Write([]byte("again\n"))
+	file.Close()
+
+	rotated, _ := file.Rotated()
+	if len(rotated) != 1 {
+		t.Fatalf("expected the old file to be removed and one rotation, got %v", rotated)
+	}
+	if content, _ := os.ReadFile(rotated[0]); string(content) != "yesterday\n" {
+		t.Fatalf("unexpected rotated content %q", content)
+	}
+	if stamp := "app-" + yesterday.Format(time.DateOnly); !strings.HasPrefix(filepath.Base(rotated[0]), stamp) {
+		t.Fatalf("expected the rotated file to be stamped with %s, got %s", stamp, rotated[0])
+	}
+	if content, _ := os.ReadFile(path); string(content) != "today\nagain\n" {
+		t.Fatalf("unexpected current content %q", content)
+	}
+}
+
+func TestRotatingFile_ReopenOnSignal(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	file, err := log.NewRotatingFile(path, log.RotationOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	file.ReopenOn(syscall.SIGHUP)
+
+	file.Write([]byte("before\n"))
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+
+	process, _ := os.FindProcess(os.Getpid())
+	if err := process.Signal(syscall.SIGHUP); err != nil {
+		t.Skipf("signals not supported: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !fileExists(path) {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the file to be reopened")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	file.Write([]byte("after\n"))
+
+	if content, _ := os.ReadFile(path + ".1"); string(content) != "before\n" {
+		t.Fatalf("unexpected moved content %q", content)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "after\n" {
+		t.Fatalf("unexpected reopened content %q", content)
+	}
+}
+
+func TestRotatingFile_RecoversFromFailedOpen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	file, err := log.NewRotatingFile(path, log.RotationOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := file.Reopen(); err == nil {
+		t.Fatal("expected the reopen to fail")
+	}
+	if _, err := file.Write([]byte("lost\n")); err == nil {
+		t.Fatal("expected the write to fail while the path is unusable")
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Write([]byte("recovered\n")); err != nil {
+		t.Fatalf("expected the file to be opened again, got %v", err)
+	}
+
+	if content, _ := os.ReadFile(path); string(content) != "recovered\n" {
+		t.Fatalf("unexpected content %q", content)
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
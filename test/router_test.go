@@ -0,0 +1,87 @@
+package router_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+func textHandler(message string) router.RequestHandler {
+	return func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+		return result.TextOk(message)
+	}
+}
+
+func doRequest(t *testing.T, handler http.Handler, method, path string, headers ...map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(method, path, nil)
+	for _, header := range headers {
+		for k, v := range header {
+			r.Header.Set(k, v)
+		}
+	}
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func routeOf(method, path string, res func(r *http.Request) result.Result) *router.Router {
+	return router.NewRouter().
+		Route(method, func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+			return res(r)
+		}, path)
+}
+
+func TestRouter_IsolatedMux(t *testing.T) {
+	public := router.NewRouter().
+		Route(http.MethodGet, textHandler("public"), "/status")
+	admin := router.NewRouter().
+		Route(http.MethodGet, textHandler("admin"), "/status")
+
+	if body := doRequest(t, public, http.MethodGet, "/status").Body.String(); body != "public" {
+		t.Fatalf("expected 'public', got %q", body)
+	}
+
+	if body := doRequest(t, admin, http.MethodGet, "/status").Body.String(); body != "admin" {
+		t.Fatalf("expected 'admin', got %q", body)
+	}
+}
+
+func TestRouter_HttptestServer(t *testing.T) {
+	route := router.NewRouter().
+		BasePath("/api").
+		Route(http.MethodGet, textHandler("hello"), "/hello")
+
+	server := httptest.NewServer(route)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/api/hello")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK || string(body) != "hello" {
+		t.Fatalf("unexpected response: %d %q", res.StatusCode, string(body))
+	}
+}
+
+func TestRouter_CorsApplied(t *testing.T) {
+	route := router.NewRouter().
+		Cors(router.PermissiveCors()).
+		Route(http.MethodGet, textHandler("hello"), "/hello")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/hello", nil)
+	r.Header.Set("Origin", "https://example.com")
+	route.ServeHTTP(w, r)
+
+	if origin := w.Header().Get("Access-Control-Allow-Origin"); origin != "https://example.com" {
+		t.Fatalf("expected CORS origin header, got %q", origin)
+	}
+}
@@ -0,0 +1,196 @@
+package router_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rafael24595/go-web/router/result"
+)
+
+func TestStream_Reader(t *testing.T) {
+	route := routeOf(http.MethodGet, "/stream", func(r *http.Request) result.Result {
+		return result.Stream(strings.NewReader("chunked body")).WithContentType("text/plain")
+	})
+
+	w := doRequest(t, route, http.MethodGet, "/stream")
+
+	if w.Code != http.StatusOK || w.Body.String() != "chunked body" {
+		t.Fatalf("unexpected response: %d %q", w.Code, w.Body.String())
+	}
+	if content := w.Header().Get("Content-Type"); content != "text/plain" {
+		t.Fatalf("unexpected content type %q", content)
+	}
+	if !w.Flushed {
+		t.Fatal("expected the stream to be flushed")
+	}
+}
+
+func TestSSE_Events(t *testing.T) {
+	route := routeOf(http.MethodGet, "/stream", func(r *http.Request) result.Result {
+		events := make(chan result.Event, 3)
+		events <- result.Event{ID: "1", Event: "greeting", Data: "hello\nworld\r\nagain\rend"}
+		events <- result.Event{ID: "2", Data: map[string]int{"count": 2}}
+		events <- result.Event{Data: "bye", Retry: 2 * time.Second}
+		close(events)
+
+		return result.SSEWithOpts(events, result.SSEOpts{Retry: time.Second})
+	})
+
+	w := doRequest(t, route, http.MethodGet, "/stream")
+
+	if content := w.Header().Get("Content-Type"); content != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", content)
+	}
+	if cache := w.Header().Get("Cache-Control"); cache != "no-cache" {
+		t.Fatalf("unexpected Cache-Control %q", cache)
+	}
+
+	expected := "retry: 1000\n\n" +
+		"id: 1\nevent: greeting\ndata: hello\ndata: world\ndata: again\ndata: end\n\n" +
+		"id: 2\ndata: {\"count\":2}\n\n" +
+		"retry: 2000\ndata: bye\n\n"
+
+	if body := w.Body.String(); body != expected {
+		t.Fatalf("unexpected body:\n%q\nexpected:\n%q", body, expected)
+	}
+}
+
+func TestSSE_Resume(t *testing.T) {
+	route := routeOf(http.MethodGet, "/stream", func(r *http.Request) result.Result {
+		return result.SSEResume(func(lastEventID string) <-chan result.Event {
+			events := make(chan result.Event, 1)
+			events <- result.Event{ID: lastEventID + "+1", Data: "next"}
+			close(events)
+			return events
+		}, result.SSEOpts{})
+	})
+
+	w := doRequest(t, route, http.MethodGet, "/stream", map[string]string{"Last-Event-ID": "41"})
+
+	if !strings.Contains(w.Body.String(), "id: 41+1\n") {
+		t.Fatalf("expected resumed event id, got %q", w.Body.String())
+	}
+}
+
+func TestSSE_HeartbeatUntilDisconnect(t *testing.T) {
+	events := make(chan result.Event)
+	route := routeOf(http.MethodGet, "/stream", func(r *http.Request) result.Result {
+		return result.SSEWithOpts(events, result.SSEOpts{Heartbeat: 5 * time.Millisecond})
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/stream", nil).WithContext(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		route.ServeHTTP(w, r)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("stream did not stop after the client disconnected")
+	}
+
+	if !strings.Contains(w.Body.String(), ": heartbeat\n\n") {
+		t.Fatalf("expected heartbeat comments, got %q", w.Body.String())
+	}
+}
+
+func TestNDJSON(t *testing.T) {
+	items := make(chan testUser, 2)
+	items <- testUser{Name: "Ada", Age: 36}
+	items <- testUser{Name: "Alan", Age: 41}
+	close(items)
+
+	route := routeOf(http.MethodGet, "/stream", func(r *http.Request) result.Result {
+		return result.NDJSON(items)
+	})
+
+	w := doRequest(t, route, http.MethodGet, "/stream")
+
+	if content := w.Header().Get("Content-Type"); content != "application/x-ndjson" {
+		t.Fatalf("unexpected content type %q", content)
+	}
+
+	expected := "{\"name\":\"Ada\",\"age\":36}\n{\"name\":\"Alan\",\"age\":41}\n"
+	if w.Body.String() != expected {
+		t.Fatalf("unexpected body %q", w.Body.String())
+	}
+}
+
+func TestNDJSON_StopsOnDisconnectWhileIdle(t *testing.T) {
+	items := make(chan testUser)
+	route := routeOf(http.MethodGet, "/stream", func(r *http.Request) result.Result {
+		return result.NDJSON(items)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/stream", nil).WithContext(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		route.ServeHTTP(w, r)
+		close(done)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("stream did not stop after the client disconnected")
+	}
+
+	if w.Body.Len() != 0 {
+		t.Fatalf("expected an empty body, got %q", w.Body.String())
+	}
+}
+
+func TestNDJSONSeq_StopsOnDisconnect(t *testing.T) {
+	produced := 0
+	infinite := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			produced++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	route := routeOf(http.MethodGet, "/stream", func(r *http.Request) result.Result {
+		return result.NDJSONSeq(infinite)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	w := httptest.NewRecorder()
+	route.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stream", nil).WithContext(ctx))
+
+	if produced != 1 || w.Body.Len() != 0 {
+		t.Fatalf("expected the sequence to stop, produced %d items and %q", produced, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	route = routeOf(http.MethodGet, "/stream", func(r *http.Request) result.Result {
+		return result.NDJSONSeq(slices.Values([]int{1, 2, 3}))
+	})
+	route.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stream", nil))
+
+	if w.Body.String() != "1\n2\n3\n" {
+		t.Fatalf("unexpected body %q", w.Body.String())
+	}
+}
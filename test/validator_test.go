@@ -0,0 +1,192 @@
+package router_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/docs"
+	"github.com/Rafael24595/go-web/router/docs/swagger"
+	"github.com/Rafael24595/go-web/router/result"
+	"github.com/Rafael24595/go-web/router/validator"
+)
+
+type testAddress struct {
+	City  string   `json:"city" validate:"required"`
+	Lines []string `json:"lines" validate:"min=1,max=3"`
+}
+
+type testAccount struct {
+	Name     string        `json:"name" validate:"required,min=2,max=8"`
+	Email    string        `json:"email" validate:"required,email"`
+	Role     string        `json:"role" validate:"oneof=admin user"`
+	Age      int           `json:"age" validate:"min=18,max=99"`
+	Code     string        `json:"code" validate:"omitempty,len=4,pattern=^[A-Z]{2}[0-9]{2}$"`
+	Nickname *string       `json:"nickname" validate:"min=3"`
+	Address  testAddress   `json:"address"`
+	Previous []testAddress `json:"previous"`
+}
+
+func validAccount() testAccount {
+	return testAccount{
+		Name:    "Ada",
+		Email:   "ada@mail.com",
+		Role:    "admin",
+		Age:     36,
+		Address: testAddress{City: "London", Lines: []string{"1 Street"}},
+	}
+}
+
+func fieldErrors(t *testing.T, err error) map[string]string {
+	t.Helper()
+
+	var errs validator.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected validator.Errors, got %v", err)
+	}
+
+	fields := make(map[string]string)
+	for _, e := range errs {
+		fields[e.Field] = e.Rule
+	}
+	return fields
+}
+
+func TestValidate_Valid(t *testing.T) {
+	account := validAccount()
+	if err := validator.Validate(&account); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidate_Rules(t *testing.T) {
+	nickname := "al"
+	account := testAccount{
+		Name:     "A",
+		Email:    "not-an-email",
+		Role:     "guest",
+		Age:      12,
+		Code:     "ab12",
+		Nickname: &nickname,
+		Address:  testAddress{Lines: []string{"1", "2", "3", "4"}},
+		Previous: []testAddress{{City: "Paris"}},
+	}
+
+	fields := fieldErrors(t, validator.Validate(account))
+
+	expected := map[string]string{
+		"name":              "min",
+		"email":             "email",
+		"role":              "oneof",
+		"age":               "min",
+		"code":              "pattern",
+		"nickname":          "min",
+		"address.city":      "required",
+		"address.lines":     "max",
+		"previous[0].lines": "min",
+	}
+
+	for field, rule := range expected {
+		if fields[field] != rule {
+			t.Errorf("expected %s to fail %s, got %q", field, rule, fields[field])
+		}
+	}
+	if len(fields) != len(expected) {
+		t.Errorf("expected %d errors, got %v", len(expected), fields)
+	}
+}
+
+func TestValidate_OmitEmptyAndNil(t *testing.T) {
+	account := validAccount()
+	account.Code = ""
+	account.Nickname = nil
+
+	if err := validator.Validate(account); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidate_UnknownRule(t *testing.T) {
+	type invalid struct {
+		Name string `validate:"unknown"`
+	}
+
+	err := validator.Validate(invalid{})
+	if err == nil {
+		t.Fatal("expected error for unknown rule")
+	}
+	if errs := make(validator.Errors, 0); errors.As(err, &errs) {
+		t.Fatalf("expected configuration error, got field errors %v", errs)
+	}
+}
+
+func TestInputJson_Validation(t *testing.T) {
+	req := newRequest(`{"name":"A","email":"ada@mail.com","role":"user","age":20,"address":{"city":"Rome","lines":["x"]}}`)
+
+	_, res := router.InputJson[testAccount](req)
+	if res == nil {
+		t.Fatal("expected validation error, got nil")
+	}
+	if res.Status() != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", res.Status())
+	}
+
+	problem, _ := res.Payload().(result.Problem)
+	errs, ok := problem.Extensions["errors"].(validator.Errors)
+	if !ok || len(errs) != 1 || errs[0].Field != "name" {
+		t.Fatalf("unexpected payload: %v", res.Payload())
+	}
+}
+
+func TestInputQuery_Validation(t *testing.T) {
+	type search struct {
+		Term string `query:"q" validate:"required"`
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/search", nil)
+	_, res := router.InputQuery[search](req)
+	if res == nil || res.Status() != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %v", res)
+	}
+}
+
+func TestSchema_ValidationRules(t *testing.T) {
+	factory := swagger.NewFactoryStructToSchema()
+	if _, err := factory.MakeSchema(docs.JSON, testAccount{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var schema swagger.Schema
+	for _, s := range factory.Components().Schemas {
+		if _, ok := s.Properties["email"]; ok {
+			schema = s
+		}
+	}
+
+	name := schema.Properties["name"]
+	if name.MinLength == nil || *name.MinLength != 2 || name.MaxLength == nil || *name.MaxLength != 8 {
+		t.Errorf("unexpected name constraints: %+v", name)
+	}
+	if schema.Properties["email"].Format != "email" {
+		t.Errorf("expected email format, got %q", schema.Properties["email"].Format)
+	}
+	if age := schema.Properties["age"]; age.Minimum == nil || *age.Minimum != 18 || age.Maximum == nil || *age.Maximum != 99 {
+		t.Errorf("unexpected age constraints: %+v", age)
+	}
+	if role := schema.Properties["role"]; len(role.Enum) != 2 || role.Enum[0] != "admin" {
+		t.Errorf("unexpected role enum: %v", role.Enum)
+	}
+	if code := schema.Properties["code"]; code.Pattern != "^[A-Z]{2}[0-9]{2}$" {
+		t.Errorf("unexpected code pattern: %q", code.Pattern)
+	}
+
+	required := make(map[string]bool)
+	for _, r := range schema.Required {
+		required[r] = true
+	}
+	if !required["name"] || !required["email"] || required["code"] || required["nickname"] {
+		t.Errorf("unexpected required list: %v", schema.Required)
+	}
+}
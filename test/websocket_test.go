@@ -0,0 +1,315 @@
+package router_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rafael24595/go-web/router"
+	"github.com/Rafael24595/go-web/router/result"
+	"github.com/Rafael24595/go-web/router/websocket"
+)
+
+const testSocketKey = "dGhlIHNhbXBsZSBub25jZQ=="
+
+type testSocket struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+	header http.Header
+}
+
+func dialSocket(t *testing.T, server *httptest.Server, path string, headers map[string]string) (*testSocket, *http.Response) {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	request := "GET " + path + " HTTP/1.1\r\nHost: " + conn.RemoteAddr().String() + "\r\n" +
+		"Upgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: " + testSocketKey + "\r\n"
+	for k, v := range headers {
+		request += k + ": " + v + "\r\n"
+	}
+
+	if _, err := conn.Write([]byte(request + "\r\n")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	res, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return &testSocket{t: t, conn: conn, reader: reader, header: res.Header}, res
+}
+
+func (s *testSocket) send(fin bool, opcode byte, rsv1 bool, payload []byte, masked bool) {
+	s.t.Helper()
+
+	header := []byte{opcode, 0}
+	if fin {
+		header[0] |= 0x80
+	}
+	if rsv1 {
+		header[0] |= 0x40
+	}
+
+	switch {
+	case len(payload) <= 125:
+		header[1] = byte(len(payload))
+	default:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header, uint16(len(payload)))
+	}
+
+	body := append([]byte(nil), payload...)
+	if masked {
+		header[1] |= 0x80
+		mask := []byte{0x12, 0x34, 0x56, 0x78}
+		header = append(header, mask...)
+		for i := range body {
+			body[i] ^= mask[i%4]
+		}
+	}
+
+	if _, err := s.conn.Write(append(header, body...)); err != nil {
+		s.t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func (s *testSocket) receive() (byte, bool, []byte) {
+	s.t.Helper()
+
+	var header [2]byte
+	if _, err := io.ReadFull(s.reader, header[:]); err != nil {
+		s.t.Fatalf("unexpected error: %v", err)
+	}
+
+	length := int(header[1] & 0x7f)
+	if length == 126 {
+		var extended [2]byte
+		io.ReadFull(s.reader, extended[:])
+		length = int(binary.BigEndian.Uint16(extended[:]))
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(s.reader, payload); err != nil {
+		s.t.Fatalf("unexpected error: %v", err)
+	}
+
+	return header[0] & 0x0f, header[0]&0x40 != 0, payload
+}
+
+func (s *testSocket) expectClose(code int) {
+	s.t.Helper()
+
+	opcode, _, payload := s.receive()
+	if opcode != 0x8 || len(payload) < 2 || int(binary.BigEndian.Uint16(payload)) != code {
+		s.t.Fatalf("expected close %d, got opcode %d payload %v", code, opcode, payload)
+	}
+}
+
+func echoSocket(conn *websocket.Conn, r *http.Request, ctx *router.Context) {
+	for {
+		kind, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		conn.WriteMessage(kind, message)
+	}
+}
+
+func TestWebSocket_Echo(t *testing.T) {
+	server := httptest.NewServer(router.NewRouter().WebSocket(echoSocket, "/echo"))
+	defer server.Close()
+
+	socket, res := dialSocket(t, server, "/echo", nil)
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("expected 101, got %d", res.StatusCode)
+	}
+	if accept := res.Header.Get("Sec-WebSocket-Accept"); accept != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("unexpected accept key %q", accept)
+	}
+
+	socket.send(true, 0x1, false, []byte("hello"), true)
+	if opcode, _, payload := socket.receive(); opcode != 0x1 || string(payload) != "hello" {
+		t.Fatalf("unexpected echo: %d %q", opcode, payload)
+	}
+
+	socket.send(false, 0x2, false, []byte("frag"), true)
+	socket.send(true, 0x9, false, []byte("ping"), true)
+	socket.send(true, 0x0, false, []byte("mented"), true)
+
+	if opcode, _, payload := socket.receive(); opcode != 0xA || string(payload) != "ping" {
+		t.Fatalf("expected pong, got %d %q", opcode, payload)
+	}
+	if opcode, _, payload := socket.receive(); opcode != 0x2 || string(payload) != "fragmented" {
+		t.Fatalf("unexpected echo: %d %q", opcode, payload)
+	}
+
+	socket.send(true, 0x8, false, binary.BigEndian.AppendUint16(nil, 1000), true)
+	socket.expectClose(websocket.CloseNormal)
+}
+
+func TestWebSocket_ContextualizersRunBeforeUpgrade(t *testing.T) {
+	route := router.NewRouter().
+		Group("/secure", func(g *router.Group) {
+			g.GroupContextualizer(func(w http.ResponseWriter, r *http.Request, ctx *router.Context) result.Result {
+				if r.Header.Get("Authorization") != "token" {
+					return result.Reject(http.StatusUnauthorized)
+				}
+				ctx.Put("user", "ada")
+				return result.Next()
+			})
+			g.WebSocket(func(conn *websocket.Conn, r *http.Request, ctx *router.Context) {
+				conn.WriteText(ctx.Getz("user").Stringd(""))
+			}, "/socket")
+		})
+
+	server := httptest.NewServer(route)
+	defer server.Close()
+
+	if _, res := dialSocket(t, server, "/secure/socket", nil); res.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", res.StatusCode)
+	}
+
+	socket, res := dialSocket(t, server, "/secure/socket", map[string]string{"Authorization": "token"})
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("expected 101, got %d", res.StatusCode)
+	}
+	if _, _, payload := socket.receive(); string(payload) != "ada" {
+		t.Fatalf("expected context value, got %q", payload)
+	}
+	socket.expectClose(websocket.CloseNormal)
+}
+
+func TestWebSocket_HandshakeErrors(t *testing.T) {
+	route := router.NewRouter().WebSocket(echoSocket, "/echo")
+
+	w := doRequest(t, route, http.MethodGet, "/echo")
+	if w.Code != http.StatusUpgradeRequired || w.Header().Get("Sec-WebSocket-Version") != "13" {
+		t.Fatalf("expected 426 with version header, got %d %v", w.Code, w.Header())
+	}
+
+	w = doRequest(t, route, http.MethodGet, "/echo", map[string]string{
+		"Upgrade":               "websocket",
+		"Connection":            "Upgrade",
+		"Sec-WebSocket-Version": "13",
+		"Sec-WebSocket-Key":     testSocketKey,
+		"Origin":                "https://evil.example",
+	})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for foreign origin, got %d", w.Code)
+	}
+}
+
+func TestWebSocket_ProtocolViolations(t *testing.T) {
+	options := websocket.DefaultOptions()
+	options.MaxMessageSize = 8
+
+	route := router.NewRouter().
+		RouteWithOptions(http.MethodGet, router.NewHandlerOptions(router.WebSocketHandler(echoSocket, options)), "/echo")
+
+	server := httptest.NewServer(route)
+	defer server.Close()
+
+	socket, _ := dialSocket(t, server, "/echo", nil)
+	socket.send(true, 0x1, false, []byte("way too long"), true)
+	socket.expectClose(websocket.CloseMessageTooBig)
+
+	socket, _ = dialSocket(t, server, "/echo", nil)
+	socket.send(true, 0x1, false, []byte("plain"), false)
+	socket.expectClose(websocket.CloseProtocolError)
+
+	socket, _ = dialSocket(t, server, "/echo", nil)
+	socket.send(true, 0x1, false, []byte{0xff, 0xfe}, true)
+	socket.expectClose(websocket.CloseInvalidPayload)
+}
+
+func TestWebSocket_DeclaredLengthIsNotAllocated(t *testing.T) {
+	options := websocket.DefaultOptions()
+	options.MaxMessageSize = 0
+
+	route := router.NewRouter().
+		RouteWithOptions(http.MethodGet, router.NewHandlerOptions(router.WebSocketHandler(echoSocket, options)), "/echo")
+
+	server := httptest.NewServer(route)
+	defer server.Close()
+
+	socket, _ := dialSocket(t, server, "/echo", nil)
+	header := binary.BigEndian.AppendUint64([]byte{0x82, 0x80 | 127}, 1<<62)
+	if _, err := socket.conn.Write(append(header, 0x12, 0x34, 0x56, 0x78)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	socket.expectClose(websocket.CloseMessageTooBig)
+
+	socket, _ = dialSocket(t, server, "/echo", nil)
+	header = binary.BigEndian.AppendUint64([]byte{0x82, 0x80 | 127}, websocket.MAX_MESSAGE_SIZE)
+	if _, err := socket.conn.Write(append(header, 0x12, 0x34, 0x56, 0x78)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	socket.send(true, 0x1, false, []byte("hello"), true)
+	socket.conn.(*net.TCPConn).CloseWrite()
+
+	if _, err := socket.reader.ReadByte(); err == nil {
+		t.Fatal("expected the connection to be dropped without a reply")
+	}
+}
+
+func TestWebSocket_Compression(t *testing.T) {
+	options := websocket.DefaultOptions()
+	options.Compression = true
+	options.Subprotocols = []string{"chat", "json"}
+
+	route := router.NewRouter().
+		RouteWithOptions(http.MethodGet, router.NewHandlerOptions(router.WebSocketHandler(echoSocket, options)), "/echo")
+
+	server := httptest.NewServer(route)
+	defer server.Close()
+
+	socket, res := dialSocket(t, server, "/echo", map[string]string{
+		"Sec-WebSocket-Extensions": "permessage-deflate; client_max_window_bits",
+		"Sec-WebSocket-Protocol":   "json, chat",
+	})
+	if !strings.HasPrefix(res.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate") {
+		t.Fatalf("expected permessage-deflate, got %q", res.Header.Get("Sec-WebSocket-Extensions"))
+	}
+	if protocol := res.Header.Get("Sec-WebSocket-Protocol"); protocol != "chat" {
+		t.Fatalf("expected server preferred subprotocol, got %q", protocol)
+	}
+
+	message := strings.Repeat("compress me ", 20)
+
+	var buffer bytes.Buffer
+	writer, _ := flate.NewWriter(&buffer, flate.BestCompression)
+	writer.Write([]byte(message))
+	writer.Flush()
+	compressed := bytes.TrimSuffix(buffer.Bytes(), []byte{0, 0, 0xff, 0xff})
+
+	socket.send(true, 0x1, true, compressed, true)
+
+	opcode, rsv1, payload := socket.receive()
+	if opcode != 0x1 || !rsv1 {
+		t.Fatalf("expected compressed text frame, got opcode %d rsv1 %v", opcode, rsv1)
+	}
+
+	reader := flate.NewReader(io.MultiReader(bytes.NewReader(payload), bytes.NewReader([]byte{0, 0, 0xff, 0xff, 1, 0, 0, 0xff, 0xff})))
+	raw, err := io.ReadAll(reader)
+	if err != nil || string(raw) != message {
+		t.Fatalf("unexpected decompressed echo: %q %v", raw, err)
+	}
+}